@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/brpaz/lib-go/health"
+	"github.com/brpaz/lib-go/log"
 )
 
 // URLCheck is a health check that verifies the availability of a URL
@@ -22,6 +23,28 @@ type URLCheck struct {
 	validStatusCodes []int
 }
 
+// UnexpectedStatusError is the error returned by the URLCheck when the target URL
+// responds with a status code that is not valid.
+type UnexpectedStatusError struct {
+	URL        string
+	StatusCode int
+	Body       string
+}
+
+// Error returns the error message, with the status code and the body of the response.
+func (e *UnexpectedStatusError) Error() string {
+	return fmt.Sprintf("unexpected response. status: %d body: %s", e.StatusCode, e.Body)
+}
+
+// LogFields returns the details of the response, logged with the error by the log package.
+func (e *UnexpectedStatusError) LogFields() []log.Field {
+	return []log.Field{
+		log.String("url", e.URL),
+		log.Int("status_code", e.StatusCode),
+		log.String("body", e.Body),
+	}
+}
+
 // Option is a function that configures the HealthService.
 type UrlCheckOption func(*URLCheck)
 
@@ -96,7 +119,7 @@ func (c *URLCheck) Check(ctx context.Context) health.CheckResult {
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.targetURL.String(), nil)
 	if err != nil {
-		errW := fmt.Errorf("Failed to create request: %v", err)
+		errW := fmt.Errorf("Failed to create request: %w", err)
 		return health.CheckResult{
 			Status:  health.StatusFail,
 			Error:   errW,
@@ -107,7 +130,7 @@ func (c *URLCheck) Check(ctx context.Context) health.CheckResult {
 	start := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		errW := fmt.Errorf("Failed to create request: %v", err)
+		errW := fmt.Errorf("Failed to create request: %w", err)
 		return health.CheckResult{
 			Status:  health.StatusFail,
 			Error:   errW,
@@ -123,7 +146,11 @@ func (c *URLCheck) Check(ctx context.Context) health.CheckResult {
 
 		b, _ := io.ReadAll(resp.Body)
 
-		errW := fmt.Errorf("unexpected response. status: %d body: %s", resp.StatusCode, string(b))
+		errW := &UnexpectedStatusError{
+			URL:        c.targetURL.String(),
+			StatusCode: resp.StatusCode,
+			Body:       string(b),
+		}
 
 		return health.CheckResult{
 			Status:  health.StatusFail,
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/brpaz/lib-go/health"
 	"github.com/brpaz/lib-go/health/checks"
@@ -67,6 +68,11 @@ func TestURLCheck_Check(t *testing.T) {
 		assert.Equal(t, health.StatusFail, result.Status)
 		assert.Error(t, result.Error)
 		assert.Equal(t, "unexpected response. status: 500 body: Internal Server Error", result.Message)
+
+		var statusErr *checks.UnexpectedStatusError
+		require.ErrorAs(t, result.Error, &statusErr)
+		assert.Equal(t, http.StatusInternalServerError, statusErr.StatusCode)
+		assert.Equal(t, ts.URL, statusErr.URL)
 	})
 
 	t.Run("Failure_OnRequestTimeout", func(t *testing.T) {
@@ -85,5 +91,6 @@ func TestURLCheck_Check(t *testing.T) {
 		assert.Equal(t, health.StatusFail, result.Status)
 		assert.Error(t, result.Error)
 		assert.Contains(t, result.Error.Error(), "context deadline exceeded")
+		assert.ErrorIs(t, result.Error, context.DeadlineExceeded)
 	})
 }
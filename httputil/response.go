@@ -5,20 +5,42 @@ import (
 	"net/http"
 )
 
+// ErrorResponse is the body of the JSON error responses.
+type ErrorResponse struct {
+	Status int    `json:"status"`
+	Error  string `json:"error"`
+}
+
 // JSON writes a JSON response with the given status code and body.
 func JSON(w http.ResponseWriter, statusCode int, body any) {
 	w.Header().Set("Content-Type", "application/json")
 
-	if body != nil {
-		err := json.NewEncoder(w).Encode(body)
-		if err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
-			return
-		}
+	if body == nil {
+		w.WriteHeader(statusCode)
+		return
+	}
+
+	// Encode the body before writing the status code, so that an encoding error can still be reported
+	data, err := json.Marshal(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
 	}
 
-	// Set the status code
 	w.WriteHeader(statusCode)
+	_, _ = w.Write(append(data, '\n'))
+}
+
+// JSONError writes a JSON error response with the given status code and message.
+// If the message is empty, the status text is used.
+func JSONError(w http.ResponseWriter, statusCode int, message string) {
+	if message == "" {
+		message = http.StatusText(statusCode)
+	}
+	JSON(w, statusCode, ErrorResponse{
+		Status: statusCode,
+		Error:  message,
+	})
 }
 
 func HandleError(w http.ResponseWriter, err error) {
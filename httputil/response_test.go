@@ -42,3 +42,40 @@ func TestJSON(t *testing.T) {
 		assert.Contains(t, rr.Body.String(), "json: unsupported type")
 	})
 }
+
+func TestJSON_StatusCode(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+
+	httputil.JSON(rr, http.StatusCreated, map[string]int{"id": 1})
+
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.JSONEq(t, `{"id": 1}`, rr.Body.String())
+}
+
+func TestJSONError(t *testing.T) {
+	t.Parallel()
+
+	t.Run("with message", func(t *testing.T) {
+		t.Parallel()
+
+		rr := httptest.NewRecorder()
+
+		httputil.JSONError(rr, http.StatusBadRequest, "invalid id")
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+		assert.JSONEq(t, `{"status": 400, "error": "invalid id"}`, rr.Body.String())
+	})
+
+	t.Run("without message", func(t *testing.T) {
+		t.Parallel()
+
+		rr := httptest.NewRecorder()
+
+		httputil.JSONError(rr, http.StatusInternalServerError, "")
+
+		assert.JSONEq(t, `{"status": 500, "error": "Internal Server Error"}`, rr.Body.String())
+	})
+}
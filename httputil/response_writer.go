@@ -0,0 +1,296 @@
+package httputil
+
+import (
+	"bufio"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+// ResponseWriterOpt is a function that configures a ResponseWriter.
+type ResponseWriterOpt func(*ResponseWriter)
+
+// WithBodyWriter copies the bytes of the response body to the provided writer, like a tee.
+// It can be used to capture the response body, for logging.
+func WithBodyWriter(w io.Writer) ResponseWriterOpt {
+	return func(rw *ResponseWriter) {
+		rw.body = w
+	}
+}
+
+// ResponseWriter is a wrapper around http.ResponseWriter that records the status code, the number of
+// bytes written and the time to first byte of the response. It is meant to be used by middlewares
+// that need to inspect the response, like the RequestLogger and Recoverer middlewares of the
+// log/middleware package.
+//
+// The methods of the optional interfaces http.Flusher, http.Hijacker, http.Pusher and io.ReaderFrom
+// are always defined, and delegate to the wrapped writer. When it does not support a feature, Hijack
+// and Push return http.ErrNotSupported, and Flush does nothing. The writer returned by Writer, which
+// is the one to pass to the next handler, only implements the optional interfaces implemented by the
+// wrapped writer, so that the type assertions of the handlers report the supported features.
+// Unwrap returns the wrapped writer, so that http.ResponseController can reach its methods,
+// like SetWriteDeadline.
+type ResponseWriter struct {
+	w     http.ResponseWriter
+	body  io.Writer
+	start time.Time
+
+	status      int
+	written     int64
+	wroteHeader bool
+	firstByte   time.Time
+}
+
+// NewResponseWriter wraps the response writer. The time to first byte is measured from its creation.
+// Usage:
+//
+//	func middleware(next http.Handler) http.Handler {
+//		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+//			rw := httputil.NewResponseWriter(w)
+//			next.ServeHTTP(rw.Writer(), r)
+//			fmt.Println(rw.Status(), rw.BytesWritten(), rw.TimeToFirstByte())
+//		})
+//	}
+func NewResponseWriter(w http.ResponseWriter, opts ...ResponseWriterOpt) *ResponseWriter {
+	rw := &ResponseWriter{
+		w:     w,
+		start: time.Now(),
+	}
+	for _, opt := range opts {
+		opt(rw)
+	}
+	return rw
+}
+
+// Writer returns a writer that records the response through rw, and implements exactly the optional
+// interfaces implemented by the wrapped writer, among http.Flusher, http.Hijacker, http.Pusher and
+// io.ReaderFrom. It also implements Unwrap, for http.ResponseController.
+func (rw *ResponseWriter) Writer() http.ResponseWriter {
+	_, flusher := rw.w.(http.Flusher)
+	_, hijacker := rw.w.(http.Hijacker)
+	_, pusher := rw.w.(http.Pusher)
+	_, readerFrom := rw.w.(io.ReaderFrom)
+
+	// The interfaces embedded in the anonymous structs are all implemented by rw,
+	// so that each combination only exposes the methods of the supported interfaces
+	base := responseWriterBase(rw)
+	switch {
+	case flusher && hijacker && pusher && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{base, rw, rw, rw, rw}
+	case flusher && hijacker && pusher:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			http.Hijacker
+			http.Pusher
+		}{base, rw, rw, rw}
+	case flusher && hijacker && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			http.Hijacker
+			io.ReaderFrom
+		}{base, rw, rw, rw}
+	case flusher && pusher && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			http.Pusher
+			io.ReaderFrom
+		}{base, rw, rw, rw}
+	case hijacker && pusher && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Hijacker
+			http.Pusher
+			io.ReaderFrom
+		}{base, rw, rw, rw}
+	case flusher && hijacker:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			http.Hijacker
+		}{base, rw, rw}
+	case flusher && pusher:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			http.Pusher
+		}{base, rw, rw}
+	case flusher && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Flusher
+			io.ReaderFrom
+		}{base, rw, rw}
+	case hijacker && pusher:
+		return struct {
+			responseWriterBase
+			http.Hijacker
+			http.Pusher
+		}{base, rw, rw}
+	case hijacker && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Hijacker
+			io.ReaderFrom
+		}{base, rw, rw}
+	case pusher && readerFrom:
+		return struct {
+			responseWriterBase
+			http.Pusher
+			io.ReaderFrom
+		}{base, rw, rw}
+	case flusher:
+		return struct {
+			responseWriterBase
+			http.Flusher
+		}{base, rw}
+	case hijacker:
+		return struct {
+			responseWriterBase
+			http.Hijacker
+		}{base, rw}
+	case pusher:
+		return struct {
+			responseWriterBase
+			http.Pusher
+		}{base, rw}
+	case readerFrom:
+		return struct {
+			responseWriterBase
+			io.ReaderFrom
+		}{base, rw}
+	default:
+		return struct{ responseWriterBase }{base}
+	}
+}
+
+// responseWriterBase is the part of the ResponseWriter exposed by all the writers returned by Writer.
+type responseWriterBase interface {
+	http.ResponseWriter
+	Unwrap() http.ResponseWriter
+}
+
+// Header returns the header map of the wrapped writer.
+func (rw *ResponseWriter) Header() http.Header {
+	return rw.w.Header()
+}
+
+// WriteHeader records the status code and writes it to the response. Informational (1xx) status
+// codes, which can be written multiple times before the final status, are not recorded,
+// except for 101 Switching Protocols.
+func (rw *ResponseWriter) WriteHeader(code int) {
+	if !rw.wroteHeader && (code >= http.StatusOK || code == http.StatusSwitchingProtocols) {
+		rw.recordHeader(code)
+	}
+	rw.w.WriteHeader(code)
+}
+
+// Write writes the data to the response, recording the number of bytes written.
+func (rw *ResponseWriter) Write(p []byte) (int, error) {
+	rw.recordHeader(http.StatusOK)
+	n, err := rw.w.Write(p)
+	rw.written += int64(n)
+	if rw.body != nil && n > 0 {
+		_, _ = rw.body.Write(p[:n])
+	}
+	return n, err
+}
+
+// ReadFrom copies the data from the reader to the response, using the io.ReaderFrom implementation
+// of the wrapped writer when available, like the sendfile optimization of net/http.
+func (rw *ResponseWriter) ReadFrom(src io.Reader) (int64, error) {
+	rf, ok := rw.w.(io.ReaderFrom)
+	if !ok || rw.body != nil {
+		// The writer is hidden from io.Copy, to not call this method again
+		return io.Copy(struct{ io.Writer }{rw}, src)
+	}
+
+	rw.recordHeader(http.StatusOK)
+	n, err := rf.ReadFrom(src)
+	rw.written += n
+	return n, err
+}
+
+// Flush sends the buffered data to the client, if the wrapped writer supports it.
+func (rw *ResponseWriter) Flush() {
+	_ = rw.FlushError()
+}
+
+// FlushError sends the buffered data to the client, returning http.ErrNotSupported
+// if the wrapped writer does not support it.
+func (rw *ResponseWriter) FlushError() error {
+	err := http.NewResponseController(rw.w).Flush()
+	if err == nil {
+		rw.recordHeader(http.StatusOK)
+	}
+	return err
+}
+
+// Hijack lets the caller take over the connection, returning http.ErrNotSupported
+// if the wrapped writer does not support it. The status of hijacked connections
+// is recorded as 101 Switching Protocols, if no status was written.
+func (rw *ResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	conn, buf, err := http.NewResponseController(rw.w).Hijack()
+	if err == nil {
+		rw.recordHeader(http.StatusSwitchingProtocols)
+	}
+	return conn, buf, err
+}
+
+// Push initiates an HTTP/2 server push, returning http.ErrNotSupported if the wrapped writer does not support it.
+func (rw *ResponseWriter) Push(target string, opts *http.PushOptions) error {
+	pusher, ok := rw.w.(http.Pusher)
+	if !ok {
+		return http.ErrNotSupported
+	}
+	return pusher.Push(target, opts)
+}
+
+// Unwrap returns the wrapped writer, for http.ResponseController.
+func (rw *ResponseWriter) Unwrap() http.ResponseWriter {
+	return rw.w
+}
+
+// Status returns the status code of the response. It returns 200 if the handler wrote the body
+// without a status code, and 0 if nothing was written yet.
+func (rw *ResponseWriter) Status() int {
+	return rw.status
+}
+
+// WroteHeader reports whether the status code was written, explicitly or by writing the body.
+func (rw *ResponseWriter) WroteHeader() bool {
+	return rw.wroteHeader
+}
+
+// BytesWritten returns the number of bytes of the response body written.
+func (rw *ResponseWriter) BytesWritten() int64 {
+	return rw.written
+}
+
+// TimeToFirstByte returns the time between the creation of the writer and the moment the status
+// code was written, or 0 if nothing was written yet.
+func (rw *ResponseWriter) TimeToFirstByte() time.Duration {
+	if !rw.wroteHeader {
+		return 0
+	}
+	return rw.firstByte.Sub(rw.start)
+}
+
+// recordHeader records the status code and the time to first byte, if no status code was written yet.
+func (rw *ResponseWriter) recordHeader(code int) {
+	if rw.wroteHeader {
+		return
+	}
+	rw.wroteHeader = true
+	rw.status = code
+	rw.firstByte = time.Now()
+}
@@ -0,0 +1,214 @@
+package httputil_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/httputil"
+)
+
+func TestResponseWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	t.Run("explicit status", func(t *testing.T) {
+		t.Parallel()
+
+		rr := httptest.NewRecorder()
+		var body bytes.Buffer
+		rw := httputil.NewResponseWriter(rr, httputil.WithBodyWriter(&body))
+		assert.False(t, rw.WroteHeader())
+		assert.Zero(t, rw.TimeToFirstByte())
+
+		time.Sleep(5 * time.Millisecond)
+		rw.WriteHeader(http.StatusCreated)
+		rw.WriteHeader(http.StatusInternalServerError)
+		_, err := rw.Write([]byte("Hello, "))
+		require.NoError(t, err)
+		_, err = rw.Write([]byte("World!"))
+		require.NoError(t, err)
+
+		assert.True(t, rw.WroteHeader())
+		assert.Equal(t, http.StatusCreated, rw.Status())
+		assert.Equal(t, int64(13), rw.BytesWritten())
+		assert.GreaterOrEqual(t, rw.TimeToFirstByte(), 5*time.Millisecond)
+		assert.Equal(t, "Hello, World!", body.String())
+		assert.Equal(t, "Hello, World!", rr.Body.String())
+	})
+
+	t.Run("implicit status", func(t *testing.T) {
+		t.Parallel()
+
+		rw := httputil.NewResponseWriter(httptest.NewRecorder())
+		_, err := rw.Write([]byte("ok"))
+		require.NoError(t, err)
+
+		assert.Equal(t, http.StatusOK, rw.Status())
+	})
+}
+
+func TestResponseWriter_ReadFrom(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	var body bytes.Buffer
+	rw := httputil.NewResponseWriter(rr, httputil.WithBodyWriter(&body))
+
+	n, err := io.Copy(rw, strings.NewReader("streamed body"))
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(13), n)
+	assert.Equal(t, int64(13), rw.BytesWritten())
+	assert.Equal(t, http.StatusOK, rw.Status())
+	assert.Equal(t, "streamed body", body.String())
+	assert.Equal(t, "streamed body", rr.Body.String())
+}
+
+func TestResponseWriter_Flush(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	var w http.ResponseWriter = httputil.NewResponseWriter(rr)
+
+	flusher, ok := w.(http.Flusher)
+	require.True(t, ok)
+	flusher.Flush()
+
+	assert.True(t, rr.Flushed)
+	assert.Equal(t, http.StatusOK, w.(*httputil.ResponseWriter).Status())
+}
+
+func TestResponseWriter_NotSupported(t *testing.T) {
+	t.Parallel()
+
+	// The writer does not implement any optional interface
+	rw := httputil.NewResponseWriter(struct{ http.ResponseWriter }{httptest.NewRecorder()})
+
+	_, _, err := rw.Hijack()
+	assert.ErrorIs(t, err, http.ErrNotSupported)
+	assert.ErrorIs(t, rw.Push("/app.js", nil), http.ErrNotSupported)
+	assert.ErrorIs(t, rw.FlushError(), http.ErrNotSupported)
+	assert.False(t, rw.WroteHeader())
+}
+
+func TestResponseWriter_Writer(t *testing.T) {
+	t.Parallel()
+
+	t.Run("writer without optional interfaces", func(t *testing.T) {
+		t.Parallel()
+
+		rw := httputil.NewResponseWriter(struct{ http.ResponseWriter }{httptest.NewRecorder()})
+		w := rw.Writer()
+
+		assert.NotImplements(t, (*http.Flusher)(nil), w)
+		assert.NotImplements(t, (*http.Hijacker)(nil), w)
+		assert.NotImplements(t, (*http.Pusher)(nil), w)
+		assert.NotImplements(t, (*io.ReaderFrom)(nil), w)
+
+		w.WriteHeader(http.StatusAccepted)
+		assert.Equal(t, http.StatusAccepted, rw.Status())
+	})
+
+	t.Run("recorder", func(t *testing.T) {
+		t.Parallel()
+
+		// The recorder only implements http.Flusher
+		rr := httptest.NewRecorder()
+		rw := httputil.NewResponseWriter(rr)
+		w := rw.Writer()
+
+		assert.NotImplements(t, (*http.Hijacker)(nil), w)
+		assert.NotImplements(t, (*http.Pusher)(nil), w)
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+		flusher.Flush()
+
+		assert.True(t, rr.Flushed)
+		assert.Equal(t, http.StatusOK, rw.Status())
+		assert.Same(t, rr, w.(interface{ Unwrap() http.ResponseWriter }).Unwrap())
+	})
+
+	t.Run("server", func(t *testing.T) {
+		t.Parallel()
+
+		supported := make(chan []bool, 1)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			wrapped := httputil.NewResponseWriter(w).Writer()
+			_, flusher := wrapped.(http.Flusher)
+			_, hijacker := wrapped.(http.Hijacker)
+			_, readerFrom := wrapped.(io.ReaderFrom)
+			supported <- []bool{flusher, hijacker, readerFrom}
+		}))
+		defer server.Close()
+
+		resp, err := http.Get(server.URL)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		assert.Equal(t, []bool{true, true, true}, <-supported)
+	})
+}
+
+func TestResponseWriter_Hijack(t *testing.T) {
+	t.Parallel()
+
+	statuses := make(chan int, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := httputil.NewResponseWriter(w)
+		conn, buf, err := rw.Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+
+		_, _ = buf.WriteString("HTTP/1.1 101 Switching Protocols\r\nConnection: Upgrade\r\nUpgrade: test\r\n\r\n")
+		_ = buf.Flush()
+		statuses <- rw.Status()
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Connection", "Upgrade")
+	req.Header.Set("Upgrade", "test")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusSwitchingProtocols, resp.StatusCode)
+	assert.Equal(t, http.StatusSwitchingProtocols, <-statuses)
+}
+
+func TestResponseWriter_ResponseController(t *testing.T) {
+	t.Parallel()
+
+	errs := make(chan error, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rc := http.NewResponseController(httputil.NewResponseWriter(w))
+		errs <- rc.SetWriteDeadline(time.Now().Add(time.Second))
+	}))
+	defer server.Close()
+
+	resp, err := http.Get(server.URL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.NoError(t, <-errs)
+}
+
+func TestResponseWriter_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	rr := httptest.NewRecorder()
+	rw := httputil.NewResponseWriter(rr)
+
+	assert.Same(t, rr, rw.Unwrap())
+}
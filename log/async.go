@@ -0,0 +1,416 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Policies applied by the AsyncLogger when its buffer is full.
+const (
+	// OverflowBlock blocks the caller until there is room in the buffer.
+	OverflowBlock = "block"
+	// OverflowDropNewest drops the entry being logged.
+	OverflowDropNewest = "drop_newest"
+	// OverflowDropOldest drops the oldest entry in the buffer, to make room for the entry being logged.
+	OverflowDropOldest = "drop_oldest"
+)
+
+// Default values of the AsyncOpts.
+const (
+	DefaultAsyncBufferSize    = 1024
+	DefaultAsyncFlushInterval = time.Second
+)
+
+// allowedOverflowPolicies lists the valid overflow policies.
+var allowedOverflowPolicies = []string{OverflowBlock, OverflowDropNewest, OverflowDropOldest}
+
+var ErrInvalidAsyncOpts = errors.New("invalid async logging options")
+
+// AsyncOpts specifies the options of the asynchronous logging.
+type AsyncOpts struct {
+	// BufferSize is the maximum number of entries waiting to be written. Defaults to DefaultAsyncBufferSize.
+	BufferSize int
+	// Policy is the policy applied when the buffer is full: OverflowBlock, OverflowDropNewest
+	// or OverflowDropOldest. Defaults to OverflowBlock.
+	Policy string
+	// FlushInterval is the interval at which the underlying logger is synced, to flush its
+	// own buffers. Defaults to DefaultAsyncFlushInterval.
+	FlushInterval time.Duration
+}
+
+// Validate checks if the async options are valid.
+func (o AsyncOpts) Validate() error {
+	if o.BufferSize < 0 || o.FlushInterval < 0 {
+		return ErrInvalidAsyncOpts
+	}
+	if o.Policy != "" && !slices.Contains(allowedOverflowPolicies, o.Policy) {
+		return fmt.Errorf("%w: policy %s. Allowed values are %v", ErrInvalidAsyncOpts, o.Policy, allowedOverflowPolicies)
+	}
+	return nil
+}
+
+// WithAsync enables the asynchronous logging with the provided options. See NewAsync.
+func WithAsync(opts AsyncOpts) LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.Async = &opts
+	}
+}
+
+// AsyncLogger is a logger that writes the entries to the underlying logger from a background
+// goroutine, through a bounded buffer, so that slow outputs do not block the callers.
+// It can wrap any Logger implementation.
+//
+// The time of the entries is the time at which they are logged, and their fields are resolved
+// at that time too: the fmt.Stringer and ObjectMarshaler values are converted, and the maps and
+// slices of the Any fields are copied, so that the values can be changed after logging.
+// Panic and Fatal entries are written synchronously, after the buffered entries. Since the
+// other entries are written by the background goroutine, the caller and the stack traces added
+// by the underlying logger point to that goroutine. Sync waits for the buffered entries to be
+// written, and Close stops the background goroutine.
+type AsyncLogger struct {
+	logger Logger
+	queue  *asyncQueue
+}
+
+// asyncEntry is an entry waiting to be written by the background goroutine.
+type asyncEntry struct {
+	logger Logger
+	ctx    context.Context
+	entry  entry
+}
+
+// asyncQueue is the buffer shared by an AsyncLogger and the child loggers created with With and Named.
+type asyncQueue struct {
+	opts   AsyncOpts
+	logger Logger
+
+	mu sync.Mutex
+	// changed is signaled when entries are taken from the buffer, or the queue is closed.
+	changed *sync.Cond
+	entries []asyncEntry
+	// writing is true while the background goroutine writes the entries taken from the buffer.
+	writing bool
+	closed  bool
+
+	wake    chan struct{}
+	stop    chan struct{}
+	done    chan struct{}
+	dropped atomic.Uint64
+}
+
+// NewAsync creates a new logger that writes the entries to the provided logger asynchronously,
+// and starts its background goroutine. Close must be called to stop it.
+// Usage:
+//
+//	logger := log.NewAsync(zapLogger, log.AsyncOpts{
+//		BufferSize: 4096,
+//		Policy:     log.OverflowDropOldest,
+//	})
+//	defer logger.Close()
+func NewAsync(logger Logger, opts AsyncOpts) *AsyncLogger {
+	if opts.BufferSize <= 0 {
+		opts.BufferSize = DefaultAsyncBufferSize
+	}
+	if opts.Policy == "" {
+		opts.Policy = OverflowBlock
+	}
+	if opts.FlushInterval <= 0 {
+		opts.FlushInterval = DefaultAsyncFlushInterval
+	}
+
+	q := &asyncQueue{
+		opts:   opts,
+		logger: logger,
+		wake:   make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+	q.changed = sync.NewCond(&q.mu)
+	go q.run()
+
+	return &AsyncLogger{
+		logger: logger,
+		queue:  q,
+	}
+}
+
+// writeEntry adds the entry to the buffer, if its level is enabled, with the current time and
+// the resolved fields. The entry is written synchronously when the logger is closed, while
+// terminating entries are written synchronously after the buffered entries.
+func (l *AsyncLogger) writeEntry(ctx context.Context, e entry) {
+	if e.terminate {
+		l.queue.wait()
+		// Skip this method too
+		e.skip++
+		writeEntry(ctx, l.logger, e)
+		return
+	}
+
+	if e.level < l.logger.GetLevel() {
+		return
+	}
+
+	if e.time.IsZero() {
+		e.time = time.Now()
+	}
+	e.fields = resolveFields(e.fields)
+
+	// The caller of the buffered entries is the background goroutine
+	queued := e
+	queued.skip = 0
+	if !l.queue.enqueue(asyncEntry{logger: l.logger, ctx: ctx, entry: queued}) {
+		e.skip++
+		writeEntry(ctx, l.logger, e)
+	}
+}
+
+// Info logs an informational message asynchronously.
+func (l *AsyncLogger) Info(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelInfo, msg: msg, fields: fields, skip: 1})
+}
+
+// Warn logs a warning message asynchronously.
+func (l *AsyncLogger) Warn(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelWarn, msg: msg, fields: fields, skip: 1})
+}
+
+// Error logs an error message asynchronously.
+func (l *AsyncLogger) Error(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelError, msg: msg, fields: fields, skip: 1})
+}
+
+// Debug logs a debug message asynchronously.
+func (l *AsyncLogger) Debug(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelDebug, msg: msg, fields: fields, skip: 1})
+}
+
+// Trace logs a trace message asynchronously.
+func (l *AsyncLogger) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelTrace, msg: msg, fields: fields, skip: 1})
+}
+
+// Panic waits for the buffered entries to be written, logs the message and then panics.
+func (l *AsyncLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelPanic, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Fatal waits for the buffered entries to be written, logs the message and then exits.
+func (l *AsyncLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelFatal, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Log logs a message at the given level asynchronously.
+func (l *AsyncLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: level, msg: msg, fields: fields, skip: 1})
+}
+
+// SetLevel updates the level of the underlying logger.
+func (l *AsyncLogger) SetLevel(level Level) error {
+	return l.logger.SetLevel(level)
+}
+
+// GetLevel returns the level of the underlying logger.
+func (l *AsyncLogger) GetLevel() Level {
+	return l.logger.GetLevel()
+}
+
+// With returns a new logger with the additional fields, sharing the buffer of this logger.
+func (l *AsyncLogger) With(fields ...Field) Logger {
+	return &AsyncLogger{
+		logger: l.logger.With(fields...),
+		queue:  l.queue,
+	}
+}
+
+// Named returns a new named logger, sharing the buffer of this logger.
+func (l *AsyncLogger) Named(name string) Logger {
+	return &AsyncLogger{
+		logger: l.logger.Named(name),
+		queue:  l.queue,
+	}
+}
+
+// Registry returns the registry of the named loggers created from the underlying logger.
+func (l *AsyncLogger) Registry() *LevelRegistry {
+	return RegistryOf(l.logger)
+}
+
+// Dropped returns the number of entries dropped because the buffer was full.
+func (l *AsyncLogger) Dropped() uint64 {
+	return l.queue.dropped.Load()
+}
+
+// Sync waits for the buffered entries to be written and then syncs the underlying logger.
+func (l *AsyncLogger) Sync() error {
+	l.queue.wait()
+	return l.queue.logger.Sync()
+}
+
+// Close writes the buffered entries, stops the background goroutine and closes the underlying logger
+// (see Close). The entries logged after Close are written synchronously.
+func (l *AsyncLogger) Close() error {
+	l.queue.mu.Lock()
+	if l.queue.closed {
+		l.queue.mu.Unlock()
+		return nil
+	}
+	l.queue.closed = true
+	l.queue.changed.Broadcast()
+	l.queue.mu.Unlock()
+
+	close(l.queue.stop)
+	<-l.queue.done
+	return Close(l.queue.logger)
+}
+
+// enqueue adds the entry to the buffer, applying the overflow policy when it is full.
+// It returns false if the queue is closed and the entry was not added.
+func (q *asyncQueue) enqueue(entry asyncEntry) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for !q.closed && len(q.entries) >= q.opts.BufferSize {
+		switch q.opts.Policy {
+		case OverflowDropNewest:
+			q.dropped.Add(1)
+			return true
+		case OverflowDropOldest:
+			clear(q.entries[:1])
+			q.entries = q.entries[1:]
+			q.dropped.Add(1)
+		default:
+			q.changed.Wait()
+		}
+	}
+	if q.closed {
+		return false
+	}
+
+	q.entries = append(q.entries, entry)
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// wait blocks until the buffer is empty and the background goroutine is not writing entries.
+func (q *asyncQueue) wait() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	for len(q.entries) > 0 || q.writing {
+		q.changed.Wait()
+	}
+}
+
+// run writes the buffered entries and periodically syncs the underlying logger, until the queue is stopped.
+func (q *asyncQueue) run() {
+	defer close(q.done)
+
+	ticker := time.NewTicker(q.opts.FlushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-q.wake:
+			q.drain()
+		case <-ticker.C:
+			_ = q.logger.Sync()
+		case <-q.stop:
+			q.drain()
+			return
+		}
+	}
+}
+
+// drain writes the buffered entries, until the buffer is empty.
+func (q *asyncQueue) drain() {
+	for {
+		q.mu.Lock()
+		batch := q.entries
+		q.entries = nil
+		q.writing = len(batch) > 0
+		q.changed.Broadcast()
+		q.mu.Unlock()
+
+		if len(batch) == 0 {
+			return
+		}
+
+		for _, queued := range batch {
+			writeEntry(queued.ctx, queued.logger, queued.entry)
+		}
+
+		q.mu.Lock()
+		q.writing = false
+		q.changed.Broadcast()
+		q.mu.Unlock()
+	}
+}
+
+// resolveFields returns a copy of the fields that does not change after logging: the fmt.Stringer
+// values are converted to strings, the ObjectMarshaler values to groups, and the maps and slices
+// of the Any fields are copied. Groups are resolved recursively.
+func resolveFields(fields []Field) []Field {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	resolved := make([]Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case StringerType:
+			f = String(f.Key, stringerValue(f.Interface))
+		case GroupType, ObjectType:
+			f = Group(f.Key, resolveFields(f.Fields())...)
+		case AnyType:
+			if f.Interface != nil {
+				f.Interface = copyValue(reflect.ValueOf(f.Interface)).Interface()
+			}
+		}
+		resolved[i] = f
+	}
+	return resolved
+}
+
+// copyValue returns a deep copy of the maps and slices of the value, including the maps and slices
+// nested in them. Other values, like pointers and structs, are returned as is.
+func copyValue(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), copyValue(iter.Value()))
+		}
+		return c
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := range v.Len() {
+			c.Index(i).Set(copyValue(v.Index(i)))
+		}
+		return c
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(copyValue(v.Elem()))
+		return c
+	default:
+		return v
+	}
+}
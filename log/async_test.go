@@ -0,0 +1,275 @@
+package log_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// blockingLogger blocks the first entry written until it is released.
+// The in-memory logger is embedded as a Logger, so that the entries are written with Log.
+type blockingLogger struct {
+	log.Logger
+	memory  *log.InMemoryLogger
+	once    sync.Once
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingLogger() *blockingLogger {
+	memory := log.NewInMemory(log.LevelInfo)
+	return &blockingLogger{
+		Logger:  memory,
+		memory:  memory,
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+}
+
+func (l *blockingLogger) Log(ctx context.Context, level log.Level, msg string, fields ...log.Field) {
+	l.once.Do(func() {
+		close(l.started)
+		<-l.release
+	})
+	l.Logger.Log(ctx, level, msg, fields...)
+}
+
+func (l *blockingLogger) Entries() []log.InMemoryLogEntry {
+	return l.memory.Entries()
+}
+
+// syncCountingLogger counts the calls to Sync.
+type syncCountingLogger struct {
+	*log.InMemoryLogger
+	syncs atomic.Int64
+}
+
+func (l *syncCountingLogger) Sync() error {
+	l.syncs.Add(1)
+	return nil
+}
+
+func TestAsyncLogger_Sync(t *testing.T) {
+	t.Parallel()
+	inner := log.NewInMemory(log.LevelInfo)
+	logger := log.NewAsync(inner, log.AsyncOpts{})
+	defer logger.Close()
+
+	ctx := context.Background()
+	expected := make([]string, 100)
+	for i := range expected {
+		expected[i] = strconv.Itoa(i)
+		logger.With(log.Int("i", i)).Info(ctx, expected[i])
+	}
+	logger.Debug(ctx, "discarded")
+
+	require.NoError(t, logger.Sync())
+	assert.Equal(t, expected, messages(inner.Entries()))
+	assert.Zero(t, logger.Dropped())
+}
+
+func TestAsyncLogger_OverflowPolicies(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		policy   string
+		expected []string
+		dropped  uint64
+	}{
+		{policy: log.OverflowDropNewest, expected: []string{"1", "2", "3"}, dropped: 1},
+		{policy: log.OverflowDropOldest, expected: []string{"1", "3", "4"}, dropped: 1},
+		{policy: log.OverflowBlock, expected: []string{"1", "2", "3", "4"}, dropped: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			t.Parallel()
+			inner := newBlockingLogger()
+			logger := log.NewAsync(inner, log.AsyncOpts{BufferSize: 2, Policy: tt.policy})
+			defer logger.Close()
+
+			ctx := context.Background()
+			logger.Info(ctx, "1")
+			<-inner.started
+
+			// The buffer holds 2 entries while the first one is being written.
+			logger.Info(ctx, "2")
+			logger.Info(ctx, "3")
+
+			if tt.policy == log.OverflowBlock {
+				done := make(chan struct{})
+				go func() {
+					defer close(done)
+					logger.Info(ctx, "4")
+				}()
+
+				select {
+				case <-done:
+					t.Fatal("expected the caller to block while the buffer is full")
+				case <-time.After(50 * time.Millisecond):
+				}
+				close(inner.release)
+				<-done
+			} else {
+				logger.Info(ctx, "4")
+				close(inner.release)
+			}
+
+			require.NoError(t, logger.Sync())
+
+			assert.Equal(t, tt.expected, messages(inner.Entries()))
+			assert.Equal(t, tt.dropped, logger.Dropped())
+		})
+	}
+}
+
+func TestAsyncLogger_Panic(t *testing.T) {
+	t.Parallel()
+	inner := log.NewInMemory(log.LevelInfo)
+	logger := log.NewAsync(inner, log.AsyncOpts{})
+	defer logger.Close()
+
+	ctx := context.Background()
+	logger.Info(ctx, "buffered")
+	assert.PanicsWithValue(t, "panic message", func() {
+		logger.Panic(ctx, "panic message")
+	})
+
+	assert.Equal(t, []string{"buffered", "panic message"}, messages(inner.Entries()))
+}
+
+// counterStringer is a fmt.Stringer whose output changes after logging.
+type counterStringer struct {
+	n atomic.Int64
+}
+
+func (c *counterStringer) String() string {
+	return strconv.FormatInt(c.n.Load(), 10)
+}
+
+func TestAsyncLogger_ResolvesEntriesWhenLogged(t *testing.T) {
+	t.Parallel()
+	// The tee writes the entries to the in-memory logger, keeping their time, after the blocking logger
+	inner := newBlockingLogger()
+	memory := log.NewInMemory(log.LevelInfo)
+	logger := log.NewAsync(log.NewTee(inner, memory), log.AsyncOpts{})
+	defer logger.Close()
+
+	ctx := context.Background()
+	logger.Info(ctx, "blocking")
+	<-inner.started
+
+	attrs := map[string]any{"status": "pending", "tags": []string{"a"}}
+	counter := &counterStringer{}
+	before := time.Now()
+	logger.Info(ctx, "resolved", log.Any("attrs", attrs), log.Stringer("counter", counter))
+	after := time.Now()
+
+	// Changing the values while the entry is buffered does not change the entry
+	attrs["status"] = "done"
+	attrs["tags"].([]string)[0] = "b"
+	counter.n.Add(1)
+	time.Sleep(10 * time.Millisecond)
+	close(inner.release)
+	require.NoError(t, logger.Sync())
+
+	entries := memory.Entries()
+	require.Len(t, entries, 2)
+	entry := entries[1]
+	assert.WithinRange(t, entry.Timestamp, before, after)
+
+	field, ok := entry.GetField("attrs")
+	require.True(t, ok)
+	assert.Equal(t, map[string]any{"status": "pending", "tags": []string{"a"}}, field.Interface)
+	field, ok = entry.GetField("counter")
+	require.True(t, ok)
+	assert.Equal(t, log.String("counter", "0"), field)
+}
+
+func TestAsyncLogger_MutatedFields(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.log")
+	inner, err := log.NewZap(log.ZapLoggerOpts{
+		Level:   log.LevelInfo,
+		Profile: log.ProfileProduction,
+		Format:  log.FormatJSON,
+		Outputs: []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+	})
+	require.NoError(t, err)
+	logger := log.NewAsync(inner, log.AsyncOpts{})
+	defer logger.Close()
+
+	// The map is encoded by the background goroutine while it is changed,
+	// which is reported by the race detector unless it was copied.
+	ctx := context.Background()
+	attrs := map[string]any{"count": 0}
+	for i := range 100 {
+		logger.Info(ctx, "mutated", log.Any("attrs", attrs))
+		attrs["count"] = i + 1
+	}
+	require.NoError(t, logger.Sync())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 100)
+	assert.Contains(t, lines[0], `"attrs":{"count":0}`)
+	assert.Contains(t, lines[99], `"attrs":{"count":99}`)
+}
+
+func TestAsyncLogger_Close(t *testing.T) {
+	t.Parallel()
+	inner := log.NewInMemory(log.LevelInfo)
+	logger := log.NewAsync(inner, log.AsyncOpts{})
+
+	ctx := context.Background()
+	logger.Info(ctx, "before close")
+	require.NoError(t, logger.Close())
+	require.NoError(t, logger.Close())
+
+	logger.Info(ctx, "after close")
+	assert.Equal(t, []string{"before close", "after close"}, messages(inner.Entries()))
+}
+
+func TestAsyncLogger_FlushInterval(t *testing.T) {
+	t.Parallel()
+	inner := &syncCountingLogger{InMemoryLogger: log.NewInMemory(log.LevelInfo)}
+	logger := log.NewAsync(inner, log.AsyncOpts{FlushInterval: 10 * time.Millisecond})
+	defer logger.Close()
+
+	assert.Eventually(t, func() bool {
+		return inner.syncs.Load() >= 2
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNew_WithAsync(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		logger, err := log.New(log.WithAdapter(log.AdapterInMemory), log.WithAsync(log.AsyncOpts{Policy: log.OverflowDropOldest}))
+		require.NoError(t, err)
+
+		require.IsType(t, &log.AsyncLogger{}, logger)
+		assert.NoError(t, logger.(*log.AsyncLogger).Close())
+	})
+
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := log.New(log.WithAsync(log.AsyncOpts{Policy: "wait"}))
+		assert.ErrorIs(t, err, log.ErrInvalidAsyncOpts)
+	})
+}
@@ -0,0 +1,330 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Environment variables read by ConfigFromEnv and NewFromEnv.
+const (
+	EnvLevel              = "LOG_LEVEL"
+	EnvFormat             = "LOG_FORMAT"
+	EnvProfile            = "LOG_PROFILE"
+	EnvAdapter            = "LOG_ADAPTER"
+	EnvOutput             = "LOG_OUTPUT"
+	EnvFilePath           = "LOG_FILE_PATH"
+	EnvFileMaxSizeMB      = "LOG_FILE_MAX_SIZE_MB"
+	EnvFileMaxAgeDays     = "LOG_FILE_MAX_AGE_DAYS"
+	EnvFileMaxBackups     = "LOG_FILE_MAX_BACKUPS"
+	EnvFileCompress       = "LOG_FILE_COMPRESS"
+	EnvSamplingEnabled    = "LOG_SAMPLING_ENABLED"
+	EnvSamplingTick       = "LOG_SAMPLING_TICK"
+	EnvSamplingFirst      = "LOG_SAMPLING_FIRST"
+	EnvSamplingThereafter = "LOG_SAMPLING_THEREAFTER"
+	EnvSamplingKeepErrors = "LOG_SAMPLING_KEEP_ERRORS"
+	EnvRedactionEnabled   = "LOG_REDACTION_ENABLED"
+	EnvRedactionKeys      = "LOG_REDACTION_KEYS"
+	EnvRedactionMask      = "LOG_REDACTION_MASK"
+	EnvSpanEvents         = "LOG_SPAN_EVENTS"
+	EnvAsyncEnabled       = "LOG_ASYNC_ENABLED"
+	EnvAsyncBufferSize    = "LOG_ASYNC_BUFFER_SIZE"
+	EnvAsyncPolicy        = "LOG_ASYNC_POLICY"
+	EnvAsyncFlushInterval = "LOG_ASYNC_FLUSH_INTERVAL"
+)
+
+var ErrInvalidConfig = errors.New("invalid log config")
+
+// Config holds the logger settings in a plain representation, that can be loaded from
+// environment variables (see ConfigFromEnv) or decoded from a configuration file.
+// Empty values keep the defaults of New.
+type Config struct {
+	// Adapter is the logger adapter, like "zap" or "slog".
+	Adapter string `json:"adapter,omitempty" yaml:"adapter,omitempty"`
+	// Level is the minimum level of the entries, like "info".
+	Level string `json:"level,omitempty" yaml:"level,omitempty"`
+	// Profile is the logger profile, "dev" or "prod".
+	Profile string `json:"profile,omitempty" yaml:"profile,omitempty"`
+	// Format is the format of the entries, like "json".
+	Format string `json:"format,omitempty" yaml:"format,omitempty"`
+	// Outputs are the destinations of the entries: "stdout", "stderr" or "file".
+	Outputs []string `json:"outputs,omitempty" yaml:"outputs,omitempty"`
+	// File configures the rotating file, when the outputs include "file".
+	File FileConfig `json:"file,omitempty" yaml:"file,omitempty"`
+	// Sampling configures the sampling of the entries.
+	Sampling SamplingConfig `json:"sampling,omitempty" yaml:"sampling,omitempty"`
+	// Redaction configures the redaction of sensitive data.
+	Redaction RedactionConfig `json:"redaction,omitempty" yaml:"redaction,omitempty"`
+	// SpanEvents records the entries at the Warn level and above as events of the active span.
+	SpanEvents bool `json:"span_events,omitempty" yaml:"span_events,omitempty"`
+	// Async configures the asynchronous logging.
+	Async AsyncConfig `json:"async,omitempty" yaml:"async,omitempty"`
+}
+
+// FileConfig holds the settings of the rotating file output. See FileOutputOpts.
+type FileConfig struct {
+	Path       string `json:"path,omitempty" yaml:"path,omitempty"`
+	MaxSizeMB  int    `json:"max_size_mb,omitempty" yaml:"max_size_mb,omitempty"`
+	MaxAgeDays int    `json:"max_age_days,omitempty" yaml:"max_age_days,omitempty"`
+	MaxBackups int    `json:"max_backups,omitempty" yaml:"max_backups,omitempty"`
+	Compress   bool   `json:"compress,omitempty" yaml:"compress,omitempty"`
+}
+
+// SamplingConfig holds the sampling settings. See SamplingOpts.
+// When both First and Thereafter are zero, they default to 100, like in the zap production config.
+type SamplingConfig struct {
+	Enabled bool `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	// Tick is a duration, like "1s".
+	Tick       string `json:"tick,omitempty" yaml:"tick,omitempty"`
+	First      int    `json:"first,omitempty" yaml:"first,omitempty"`
+	Thereafter int    `json:"thereafter,omitempty" yaml:"thereafter,omitempty"`
+	KeepErrors bool   `json:"keep_errors,omitempty" yaml:"keep_errors,omitempty"`
+}
+
+// RedactionConfig holds the redaction settings. When enabled, the DefaultRedactionOpts are used,
+// with the additional keys and the mask, if set.
+type RedactionConfig struct {
+	Enabled bool     `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	Keys    []string `json:"keys,omitempty" yaml:"keys,omitempty"`
+	Mask    string   `json:"mask,omitempty" yaml:"mask,omitempty"`
+}
+
+// AsyncConfig holds the asynchronous logging settings. See AsyncOpts.
+type AsyncConfig struct {
+	Enabled    bool   `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	BufferSize int    `json:"buffer_size,omitempty" yaml:"buffer_size,omitempty"`
+	Policy     string `json:"policy,omitempty" yaml:"policy,omitempty"`
+	// FlushInterval is a duration, like "1s".
+	FlushInterval string `json:"flush_interval,omitempty" yaml:"flush_interval,omitempty"`
+}
+
+// ConfigFromEnv loads the logger settings from the environment variables, like LOG_LEVEL and LOG_FORMAT.
+// Lists, like LOG_OUTPUT and LOG_REDACTION_KEYS, are comma-separated.
+func ConfigFromEnv() (Config, error) {
+	return configFromLookup(os.LookupEnv)
+}
+
+// ConfigFromMap loads the logger settings from a map, like the ones returned by configuration
+// libraries after decoding a file. The keys are the JSON names of the Config fields, with nested
+// maps for the file, sampling and redaction settings.
+func ConfigFromMap(values map[string]any) (Config, error) {
+	var cfg Config
+
+	data, err := json.Marshal(values)
+	if err != nil {
+		return cfg, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return cfg, nil
+}
+
+// NewFromEnv creates a new logger configured from the environment variables. The provided
+// options are applied after the ones from the environment, so they take precedence.
+// Usage:
+//
+//	// LOG_LEVEL=debug LOG_FORMAT=logfmt LOG_SAMPLING_ENABLED=true LOG_SAMPLING_FIRST=10
+//	logger, err := log.NewFromEnv()
+func NewFromEnv(options ...LoggerOpt) (Logger, error) {
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	return FromConfig(cfg, options...)
+}
+
+// FromConfig creates a new logger with the provided settings. The provided options are
+// applied after the ones from the config, so they take precedence.
+func FromConfig(cfg Config, options ...LoggerOpt) (Logger, error) {
+	opts, err := cfg.Options()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create logger: %w", err)
+	}
+	return New(append(opts, options...)...)
+}
+
+// Options converts the settings to the options accepted by New.
+// The values are validated by New, except for the ones that must be parsed, like the level.
+func (c Config) Options() ([]LoggerOpt, error) {
+	var opts []LoggerOpt
+
+	if c.Adapter != "" {
+		opts = append(opts, WithAdapter(c.Adapter))
+	}
+	if c.Profile != "" {
+		opts = append(opts, WithProfile(c.Profile))
+	}
+	if c.Format != "" {
+		opts = append(opts, WithFormat(c.Format))
+	}
+	if c.Level != "" {
+		level, err := LevelFromString(c.Level)
+		if err != nil {
+			return nil, fmt.Errorf("%w: level %q: %w", ErrInvalidConfig, c.Level, err)
+		}
+		opts = append(opts, WithLevel(level))
+	}
+
+	for _, output := range c.Outputs {
+		switch output {
+		case OutputFile:
+			opts = append(opts, WithRotatingFile(FileOutputOpts{
+				Path:       c.File.Path,
+				MaxSizeMB:  c.File.MaxSizeMB,
+				MaxAgeDays: c.File.MaxAgeDays,
+				MaxBackups: c.File.MaxBackups,
+				Compress:   c.File.Compress,
+			}))
+		default:
+			opts = append(opts, WithOutput(Output{Type: output}))
+		}
+	}
+
+	if c.Sampling.Enabled {
+		var tick time.Duration
+		if c.Sampling.Tick != "" {
+			var err error
+			tick, err = time.ParseDuration(c.Sampling.Tick)
+			if err != nil {
+				return nil, fmt.Errorf("%w: sampling tick %q: %w", ErrInvalidConfig, c.Sampling.Tick, err)
+			}
+		}
+		opts = append(opts, WithSampling(SamplingOpts{
+			Tick:       tick,
+			First:      c.Sampling.First,
+			Thereafter: c.Sampling.Thereafter,
+			KeepErrors: c.Sampling.KeepErrors,
+		}))
+	}
+
+	if c.Redaction.Enabled {
+		redaction := DefaultRedactionOpts()
+		redaction.Keys = append(redaction.Keys, c.Redaction.Keys...)
+		if c.Redaction.Mask != "" {
+			redaction.Mask = c.Redaction.Mask
+		}
+		opts = append(opts, WithRedaction(redaction))
+	}
+
+	if c.SpanEvents {
+		opts = append(opts, WithSpanEvents())
+	}
+
+	if c.Async.Enabled {
+		var interval time.Duration
+		if c.Async.FlushInterval != "" {
+			var err error
+			interval, err = time.ParseDuration(c.Async.FlushInterval)
+			if err != nil {
+				return nil, fmt.Errorf("%w: async flush interval %q: %w", ErrInvalidConfig, c.Async.FlushInterval, err)
+			}
+		}
+		opts = append(opts, WithAsync(AsyncOpts{
+			BufferSize:    c.Async.BufferSize,
+			Policy:        c.Async.Policy,
+			FlushInterval: interval,
+		}))
+	}
+
+	return opts, nil
+}
+
+// configFromLookup loads the logger settings from the environment variables returned by lookup.
+func configFromLookup(lookup func(key string) (string, bool)) (Config, error) {
+	env := envReader{lookup: lookup}
+
+	cfg := Config{
+		Adapter: env.string(EnvAdapter),
+		Level:   env.string(EnvLevel),
+		Profile: env.string(EnvProfile),
+		Format:  env.string(EnvFormat),
+		Outputs: env.list(EnvOutput),
+		File: FileConfig{
+			Path:       env.string(EnvFilePath),
+			MaxSizeMB:  env.int(EnvFileMaxSizeMB),
+			MaxAgeDays: env.int(EnvFileMaxAgeDays),
+			MaxBackups: env.int(EnvFileMaxBackups),
+			Compress:   env.bool(EnvFileCompress),
+		},
+		Sampling: SamplingConfig{
+			Enabled:    env.bool(EnvSamplingEnabled),
+			Tick:       env.string(EnvSamplingTick),
+			First:      env.int(EnvSamplingFirst),
+			Thereafter: env.int(EnvSamplingThereafter),
+			KeepErrors: env.bool(EnvSamplingKeepErrors),
+		},
+		Redaction: RedactionConfig{
+			Enabled: env.bool(EnvRedactionEnabled),
+			Keys:    env.list(EnvRedactionKeys),
+			Mask:    env.string(EnvRedactionMask),
+		},
+		SpanEvents: env.bool(EnvSpanEvents),
+		Async: AsyncConfig{
+			Enabled:       env.bool(EnvAsyncEnabled),
+			BufferSize:    env.int(EnvAsyncBufferSize),
+			Policy:        env.string(EnvAsyncPolicy),
+			FlushInterval: env.string(EnvAsyncFlushInterval),
+		},
+	}
+
+	if err := errors.Join(env.errs...); err != nil {
+		return Config{}, fmt.Errorf("%w: %w", ErrInvalidConfig, err)
+	}
+	return cfg, nil
+}
+
+// envReader reads and parses environment variables, collecting the parsing errors.
+type envReader struct {
+	lookup func(key string) (string, bool)
+	errs   []error
+}
+
+// string returns the trimmed value of the variable, or an empty string if it is not set.
+func (r *envReader) string(key string) string {
+	value, _ := r.lookup(key)
+	return strings.TrimSpace(value)
+}
+
+// list returns the non-empty comma-separated values of the variable.
+func (r *envReader) list(key string) []string {
+	var values []string
+	for _, value := range strings.Split(r.string(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// int returns the value of the variable as an integer, or zero if it is not set.
+func (r *envReader) int(key string) int {
+	value := r.string(key)
+	if value == "" {
+		return 0
+	}
+
+	i, err := strconv.Atoi(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s must be an integer: %q", key, value))
+	}
+	return i
+}
+
+// bool returns the value of the variable as a bool, or false if it is not set.
+func (r *envReader) bool(key string) bool {
+	value := r.string(key)
+	if value == "" {
+		return false
+	}
+
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		r.errs = append(r.errs, fmt.Errorf("%s must be a boolean: %q", key, value))
+	}
+	return b
+}
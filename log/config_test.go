@@ -0,0 +1,176 @@
+package log_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+func TestConfigFromEnv(t *testing.T) {
+	t.Setenv(log.EnvAdapter, "slog")
+	t.Setenv(log.EnvLevel, "debug")
+	t.Setenv(log.EnvProfile, "dev")
+	t.Setenv(log.EnvFormat, "logfmt")
+	t.Setenv(log.EnvOutput, "stdout, file")
+	t.Setenv(log.EnvFilePath, "/var/log/app.log")
+	t.Setenv(log.EnvFileMaxSizeMB, "50")
+	t.Setenv(log.EnvFileCompress, "true")
+	t.Setenv(log.EnvSamplingEnabled, "true")
+	t.Setenv(log.EnvSamplingTick, "2s")
+	t.Setenv(log.EnvSamplingFirst, "10")
+	t.Setenv(log.EnvSamplingThereafter, "100")
+	t.Setenv(log.EnvRedactionEnabled, "1")
+	t.Setenv(log.EnvRedactionKeys, "ssn,pin")
+
+	cfg, err := log.ConfigFromEnv()
+	require.NoError(t, err)
+
+	assert.Equal(t, log.Config{
+		Adapter: "slog",
+		Level:   "debug",
+		Profile: "dev",
+		Format:  "logfmt",
+		Outputs: []string{"stdout", "file"},
+		File:    log.FileConfig{Path: "/var/log/app.log", MaxSizeMB: 50, Compress: true},
+		Sampling: log.SamplingConfig{
+			Enabled:    true,
+			Tick:       "2s",
+			First:      10,
+			Thereafter: 100,
+		},
+		Redaction: log.RedactionConfig{Enabled: true, Keys: []string{"ssn", "pin"}},
+	}, cfg)
+}
+
+func TestConfigFromEnv_InvalidValues(t *testing.T) {
+	t.Setenv(log.EnvFileMaxSizeMB, "big")
+	t.Setenv(log.EnvSamplingEnabled, "maybe")
+
+	_, err := log.ConfigFromEnv()
+	require.ErrorIs(t, err, log.ErrInvalidConfig)
+	assert.ErrorContains(t, err, log.EnvFileMaxSizeMB)
+	assert.ErrorContains(t, err, log.EnvSamplingEnabled)
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Run("Valid", func(t *testing.T) {
+		t.Setenv(log.EnvAdapter, log.AdapterInMemory)
+		t.Setenv(log.EnvLevel, "WARN")
+
+		logger, err := log.NewFromEnv()
+		require.NoError(t, err)
+
+		assert.IsType(t, &log.InMemoryLogger{}, logger)
+		assert.Equal(t, log.LevelWarn, logger.GetLevel())
+	})
+
+	t.Run("OptionsTakePrecedence", func(t *testing.T) {
+		t.Setenv(log.EnvAdapter, log.AdapterInMemory)
+		t.Setenv(log.EnvLevel, "warn")
+
+		logger, err := log.NewFromEnv(log.WithLevel(log.LevelDebug))
+		require.NoError(t, err)
+
+		assert.Equal(t, log.LevelDebug, logger.GetLevel())
+	})
+
+	t.Run("InvalidFormat", func(t *testing.T) {
+		t.Setenv(log.EnvFormat, "xml")
+
+		_, err := log.NewFromEnv()
+		assert.ErrorIs(t, err, log.ErrInvalidFormat)
+	})
+
+	t.Run("FileWithoutPath", func(t *testing.T) {
+		t.Setenv(log.EnvOutput, "file")
+
+		_, err := log.NewFromEnv()
+		assert.ErrorIs(t, err, log.ErrMissingOutputPath)
+	})
+}
+
+func TestFromConfig(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		cfg      log.Config
+		expected log.Logger
+		wantErr  error
+	}{
+		{name: "Defaults", cfg: log.Config{}, expected: &log.ZapAdapter{}},
+		{name: "Sampling", cfg: log.Config{Sampling: log.SamplingConfig{Enabled: true, First: 10}}, expected: &log.SampledLogger{}},
+		{name: "Redaction", cfg: log.Config{Redaction: log.RedactionConfig{Enabled: true}}, expected: &log.RedactingLogger{}},
+		{name: "InvalidLevel", cfg: log.Config{Level: "verbose"}, wantErr: log.ErrInvalidLevel},
+		{name: "InvalidTick", cfg: log.Config{Sampling: log.SamplingConfig{Enabled: true, Tick: "soon"}}, wantErr: log.ErrInvalidConfig},
+		{name: "InvalidSampling", cfg: log.Config{Sampling: log.SamplingConfig{Enabled: true, First: -1}}, wantErr: log.ErrInvalidSamplingOpts},
+		{name: "InvalidAdapter", cfg: log.Config{Adapter: "logrus"}, wantErr: log.ErrInvalidAdapter},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger, err := log.FromConfig(tt.cfg)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.IsType(t, tt.expected, logger)
+		})
+	}
+}
+
+func TestFromConfig_SamplingDefaults(t *testing.T) {
+	t.Parallel()
+
+	logger, err := log.FromConfig(log.Config{
+		Adapter:  log.AdapterInMemory,
+		Sampling: log.SamplingConfig{Enabled: true},
+	})
+	require.NoError(t, err)
+	require.IsType(t, &log.SampledLogger{}, logger)
+
+	for range 200 {
+		logger.Info(context.Background(), "request handled")
+	}
+
+	// The first 100 entries and then every 100th entry are logged
+	assert.Equal(t, log.SamplingStats{Logged: 101, Dropped: 99}, logger.(*log.SampledLogger).Stats())
+}
+
+func TestConfigFromMap(t *testing.T) {
+	t.Parallel()
+
+	t.Run("Valid", func(t *testing.T) {
+		t.Parallel()
+
+		cfg, err := log.ConfigFromMap(map[string]any{
+			"level":   "error",
+			"outputs": []string{"stderr"},
+			"sampling": map[string]any{
+				"enabled": true,
+				"first":   5,
+			},
+		})
+		require.NoError(t, err)
+
+		assert.Equal(t, log.Config{
+			Level:    "error",
+			Outputs:  []string{"stderr"},
+			Sampling: log.SamplingConfig{Enabled: true, First: 5},
+		}, cfg)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := log.ConfigFromMap(map[string]any{"level": 10})
+		assert.ErrorIs(t, err, log.ErrInvalidConfig)
+	})
+}
@@ -2,13 +2,79 @@ package log
 
 import (
 	"context"
+	"slices"
 
 	"go.opentelemetry.io/otel/trace"
 )
 
 type ctxKeyType struct{}
 
-var loggerCtxKey = ctxKeyType{}
+type ctxFieldsKeyType struct{}
+
+type ctxRedactorKeyType struct{}
+
+var (
+	loggerCtxKey   = ctxKeyType{}
+	fieldsCtxKey   = ctxFieldsKeyType{}
+	redactorCtxKey = ctxRedactorKeyType{}
+)
+
+// ContextExtractor extracts log fields from a context. The loggers call their extractors on
+// every log entry, adding the extracted fields to the entry.
+type ContextExtractor func(ctx context.Context) []Field
+
+// defaultContextExtractors are the extractors used by the loggers when none are provided.
+var defaultContextExtractors = []ContextExtractor{
+	ExtractTraceIDFieldsFromContext,
+	FieldsFromContext,
+}
+
+// DefaultContextExtractors returns the extractors used by default, which add the trace ids
+// and the fields attached to the context with ContextWithFields.
+func DefaultContextExtractors() []ContextExtractor {
+	return append([]ContextExtractor(nil), defaultContextExtractors...)
+}
+
+// WithContextExtractors sets the extractors used to add fields from the context to every entry,
+// replacing the default ones. To keep the default extractors, include DefaultContextExtractors:
+//
+//	log.New(log.WithContextExtractors(append(log.DefaultContextExtractors(), tenantFields)...))
+//
+// Calling it without extractors disables the extraction, like WithoutContextExtractors.
+func WithContextExtractors(extractors ...ContextExtractor) LoggerOpt {
+	return func(o *LoggerOpts) {
+		// A nil slice means the default extractors, so an empty one is kept non-nil
+		o.ContextExtractors = append([]ContextExtractor{}, extractors...)
+	}
+}
+
+// WithoutContextExtractors disables the extraction of fields from the context,
+// including the trace ids and the fields attached with ContextWithFields.
+func WithoutContextExtractors() LoggerOpt {
+	return WithContextExtractors()
+}
+
+// extractContextFields returns the fields extracted from the context by all the extractors.
+// If extractors is nil, the default extractors are used, while an empty slice extracts no fields.
+// The fields are redacted when the entry is written through a RedactingLogger.
+func extractContextFields(ctx context.Context, extractors []ContextExtractor) []Field {
+	if ctx == nil {
+		return nil
+	}
+	if extractors == nil {
+		extractors = defaultContextExtractors
+	}
+
+	var fields []Field
+	for _, extract := range extractors {
+		fields = append(fields, extract(ctx)...)
+	}
+
+	if redactor, ok := ctx.Value(redactorCtxKey).(*Redactor); ok && redactor != nil {
+		return redactor.Redact(fields...)
+	}
+	return fields
+}
 
 // FromContext retrieves the logger instance from the provided context.
 // If no logger is present in the context, it falls back to the global logger.
@@ -34,6 +100,28 @@ func ContextWithLogger(parentCtx context.Context, logger Logger) context.Context
 	return context.WithValue(parentCtx, loggerCtxKey, logger)
 }
 
+// ContextWithFields returns a new context with the provided fields, added to the fields already
+// attached to the parent context. The fields are added to every entry logged with the context.
+// Usage:
+//
+//	ctx = log.ContextWithFields(ctx, log.String("request_id", requestID), log.String("tenant_id", tenantID))
+//	logger.Info(ctx, "order created") // includes request_id and tenant_id
+func ContextWithFields(parentCtx context.Context, fields ...Field) context.Context {
+	if parentCtx == nil {
+		parentCtx = context.TODO()
+	}
+	return context.WithValue(parentCtx, fieldsCtxKey, slices.Concat(FieldsFromContext(parentCtx), fields))
+}
+
+// FieldsFromContext returns the fields attached to the context with ContextWithFields.
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(fieldsCtxKey).([]Field)
+	return fields
+}
+
 // ExtractTraceIDFieldsFromContext extracts trace-related fields from the given context
 // if OpenTelemetry tracing information is available.
 // The extracted fields include "traceId" and "spanId".
@@ -1,11 +1,15 @@
 package log_test
 
 import (
+	"bytes"
 	"context"
+	"log/slog"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/brpaz/lib-go/log"
 )
@@ -88,3 +92,121 @@ func TestExtractTraceFieldsFromContext(t *testing.T) {
 		assert.Contains(t, fields, log.String("spanId", mockSpanID.String()))
 	})
 }
+
+func TestContextWithFields(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fields are added to the fields of the parent context", func(t *testing.T) {
+		t.Parallel()
+
+		parent := log.ContextWithFields(context.Background(), log.String("request_id", "abc"))
+		ctx := log.ContextWithFields(parent, log.String("user_id", "42"))
+
+		assert.Equal(t, []log.Field{log.String("request_id", "abc")}, log.FieldsFromContext(parent))
+		assert.Equal(t, []log.Field{log.String("request_id", "abc"), log.String("user_id", "42")}, log.FieldsFromContext(ctx))
+	})
+
+	t.Run("context without fields should return no fields", func(t *testing.T) {
+		t.Parallel()
+
+		assert.Empty(t, log.FieldsFromContext(context.Background()))
+		//nolint:staticcheck
+		assert.Empty(t, log.FieldsFromContext(nil))
+	})
+}
+
+func TestContextFields_Adapters(t *testing.T) {
+	t.Parallel()
+
+	ctx := log.ContextWithFields(context.Background(), log.String("tenant_id", "acme"))
+
+	t.Run("InMemory", func(t *testing.T) {
+		t.Parallel()
+		logger := log.NewInMemory(log.LevelInfo)
+
+		logger.Info(ctx, "message", log.String("key", "value"))
+
+		entries := logger.Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, []log.Field{log.String("key", "value"), log.String("tenant_id", "acme")}, entries[0].Fields)
+	})
+
+	t.Run("Zap", func(t *testing.T) {
+		t.Parallel()
+		buf := &bytes.Buffer{}
+
+		createTestLogger(buf, zapcore.InfoLevel).Info(ctx, "message")
+
+		assertContainsJSONField(t, buf.String(), "tenant_id", "acme")
+	})
+
+	t.Run("Slog", func(t *testing.T) {
+		t.Parallel()
+		buf := &bytes.Buffer{}
+
+		createTestSlogLogger(buf, slog.LevelInfo).Info(ctx, "message")
+
+		assertContainsJSONField(t, buf.String(), "tenant_id", "acme")
+	})
+}
+
+func TestWithContextExtractors(t *testing.T) {
+	t.Parallel()
+
+	type tenantKey struct{}
+	extractTenant := func(ctx context.Context) []log.Field {
+		if tenant, ok := ctx.Value(tenantKey{}).(string); ok {
+			return []log.Field{log.String("tenant", tenant)}
+		}
+		return nil
+	}
+
+	spanContext := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID([16]byte{1, 2, 3, 4}),
+		SpanID:  trace.SpanID([8]byte{5, 6, 7, 8}),
+	})
+	ctx := trace.ContextWithSpanContext(context.WithValue(context.Background(), tenantKey{}, "acme"), spanContext)
+
+	t.Run("replaces the default extractors", func(t *testing.T) {
+		t.Parallel()
+		logger, err := log.New(log.WithAdapter(log.AdapterInMemory), log.WithContextExtractors(extractTenant))
+		require.NoError(t, err)
+
+		logger.Info(ctx, "message")
+
+		entries := logger.(*log.InMemoryLogger).Entries()
+		require.Len(t, entries, 1)
+		assert.Equal(t, []log.Field{log.String("tenant", "acme")}, entries[0].Fields)
+	})
+
+	t.Run("extends the default extractors", func(t *testing.T) {
+		t.Parallel()
+		logger, err := log.New(
+			log.WithAdapter(log.AdapterInMemory),
+			log.WithContextExtractors(append(log.DefaultContextExtractors(), extractTenant)...),
+		)
+		require.NoError(t, err)
+
+		logger.With(log.String("key", "value")).Info(ctx, "message")
+
+		entries := logger.(*log.InMemoryLogger).Entries()
+		require.Len(t, entries, 1)
+		assert.Len(t, entries[0].Fields, 4)
+		assert.Contains(t, entries[0].Fields, log.String("tenant", "acme"))
+		assert.Contains(t, entries[0].Fields, log.String("traceId", spanContext.TraceID().String()))
+	})
+	t.Run("disables the extraction", func(t *testing.T) {
+		t.Parallel()
+
+		for _, opt := range []log.LoggerOpt{log.WithContextExtractors(), log.WithoutContextExtractors()} {
+			logger, err := log.New(log.WithAdapter(log.AdapterInMemory), opt)
+			require.NoError(t, err)
+
+			logger.Info(log.ContextWithFields(ctx, log.String("key", "value")), "message")
+
+			entries := logger.(*log.InMemoryLogger).Entries()
+			require.Len(t, entries, 1)
+			assert.Empty(t, entries[0].Fields)
+		}
+	})
+}
@@ -2,15 +2,31 @@
 //
 // It is designed to be simple to use and easy to configure. The package provides
 // a generic Logger interface that can be implemented by various logging adapters.
-// Built-in adapters include Zap (high-performance production logging), InMemory
-// (log capturing for testing), and Noop (no-operation logging).
+// Built-in adapters include Zap (high-performance production logging), Slog (backed
+// by the standard library log/slog package), InMemory (log capturing for testing),
+// and Noop (no-operation logging).
 //
 // Key Features:
 //
 // - Adapters:
 //   - Zap: A high-performance, production-ready logging adapter.
-//   - InMemory: Suitable for testing or in-memory log capturing.
+//   - Slog: An adapter backed by the standard library log/slog package.
+//   - InMemory: Suitable for testing or in-memory log capturing. It is safe for concurrent use and
+//     supports querying the entries (Filter, WaitFor). See the log/testutil package for assertions.
+//   - RingBuffer: Keeps the most recent entries in a bounded buffer, to inspect them in production
+//     through the log/handler.LogsHandler. See NewRingBuffer.
+//   - Otel: Emits the entries through the OpenTelemetry Logs API, so they are exported with the
+//     traces and correlated by trace and span id. See NewOtel and tracing.WithLogs.
 //   - **Noop**: A no-operation adapter, ideal for disabling logging.
+//   - Tee: Fans out every entry to multiple loggers, each one keeping its own level. See NewTee.
+//
+// - Levels:
+//   - Trace, Debug, Info, Warn and Error, plus Panic (logs and then panics) and Fatal
+//     (logs, flushes and then exits). Logger.Log writes at any level without terminating.
+//
+// - Named loggers:
+//   - Logger.Named returns a child logger for a component (like "db" or "http") with its own
+//     level, which can be changed at runtime by name through the LevelRegistry (see RegistryOf).
 //
 // - Profiles:
 //   - ProfileDevelopment: Configures the logger for development environments, with
@@ -19,7 +35,53 @@
 //
 // - Formats:
 //   - json: Logs are output in structured JSON format for log aggregators.
-//   - fmt: Logs are output in a human-readable format for development.
+//   - logfmt: Logs are output as key=value pairs, in the logfmt format.
+//   - console: Logs are output in a human-readable format for development, with
+//     colored levels when writing to a terminal.
+//   - The format defaults to console in the development profile and json in the production profile.
+//
+// - Outputs:
+//   - stdout and stderr: Logs are written to the standard streams (stderr by default).
+//   - file: Logs are written to a file, rotated by size and age, with optional gzip
+//     compression of the rotated files. See WithRotatingFile. The files are closed by Close.
+//
+// - Sampling:
+//   - NewSampler (or the WithSampling option) limits the entries logged per message and
+//     level on hot paths, keeping counters of the logged and dropped entries.
+//
+// - Redaction:
+//   - NewRedactingLogger (or the WithRedaction option) masks sensitive field keys, like
+//     passwords and tokens, and values matching patterns, like emails and card numbers.
+//     Error messages, the maps and slices of the Any fields and the context fields are redacted too.
+//
+// - Span events:
+//   - NewSpanEventLogger (or the WithSpanEvents option) records the Warn and Error entries as events
+//     of the recording span in the context, and Error entries also set the span status to error.
+//
+// - Async:
+//   - NewAsync (or the WithAsync option) writes the entries from a background goroutine through a
+//     bounded buffer, which blocks or drops entries when full. Sync drains the buffer, and Close stops it.
+//
+// - Context fields:
+//   - ContextWithFields attaches fields (like request or tenant ids) to a context, and every entry
+//     logged with that context includes them. Fields are extracted from the context by pluggable
+//     extractors (see WithContextExtractors); by default, the trace ids and the context fields.
+//
+// - Errors:
+//   - Error fields (see Err) include the messages of the errors they wrap, under "<key>_causes",
+//     and the fields of the errors in the chain implementing ObjectMarshaler, under "<key>_fields".
+//   - The Zap and Slog adapters add the stack trace to the entries at the Error level and above
+//     (Warn in the development profile). See WithStacktraceLevel and WithoutStacktrace.
+//     The entries with a Stacktrace field, like a recovered panic, only include that stack trace.
+//
+// - Configuration:
+//   - NewFromEnv creates a logger from the LOG_* environment variables (like LOG_LEVEL, LOG_FORMAT
+//     and LOG_OUTPUT), and FromConfig from a Config, which can be decoded from a configuration file
+//     or loaded from a map with ConfigFromMap. The settings are validated like the options of New.
+//
+// - Slog interoperability:
+//   - NewSlogHandler returns a slog.Handler that forwards records to any Logger, so
+//     libraries logging through log/slog share the same output, level and trace ids.
 //
 // Example Usage:
 //
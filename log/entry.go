@@ -0,0 +1,65 @@
+package log
+
+import (
+	"context"
+	"os"
+	"time"
+)
+
+// entry is a log entry passed by the loggers that wrap other loggers, like the TeeLogger,
+// to the loggers they wrap, through their entryWriter implementation.
+type entry struct {
+	level  Level
+	msg    string
+	fields []Field
+	// time is the time of the entry. When zero, the time at which the entry is written is used.
+	time time.Time
+	// pc is the program counter of the code that logged the entry, reported as its caller, like
+	// the PC of the slog records. When zero, the caller is found by skipping the skip frames.
+	pc uintptr
+	// skip is the number of stack frames between the code that logged the entry and the writeEntry
+	// method receiving it, skipped when reporting the caller of the entry.
+	skip int
+	// terminate reports whether the entry panics or exits after being written, for the Panic and
+	// Fatal levels, like the Panic and Fatal methods. Otherwise, the entry is written like with Log.
+	terminate bool
+}
+
+// entryWriter is implemented by the loggers that write the entries of the loggers that wrap them,
+// keeping the caller and the time of the entries, which would otherwise point to the wrappers.
+type entryWriter interface {
+	writeEntry(ctx context.Context, e entry)
+}
+
+// writeEntry writes the entry with the logger, through its entryWriter implementation if available.
+// Otherwise, the entry is written with the Panic or Fatal methods for terminating entries, and Log
+// for the others.
+func writeEntry(ctx context.Context, logger Logger, e entry) {
+	if w, ok := logger.(entryWriter); ok {
+		// Skip this function too
+		e.skip++
+		w.writeEntry(ctx, e)
+		return
+	}
+
+	switch {
+	case e.terminate && e.level == LevelPanic:
+		logger.Panic(ctx, e.msg, e.fields...)
+	case e.terminate && e.level == LevelFatal:
+		logger.Fatal(ctx, e.msg, e.fields...)
+	default:
+		logger.Log(ctx, e.level, e.msg, e.fields...)
+	}
+}
+
+// terminate panics with the message for the Panic level, or syncs the logger and exits for
+// the Fatal level, after the entry was written. It does nothing for the other levels.
+func terminate(logger Logger, level Level, msg string) {
+	switch level {
+	case LevelPanic:
+		panic(msg)
+	case LevelFatal:
+		_ = logger.Sync()
+		os.Exit(1)
+	}
+}
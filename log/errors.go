@@ -0,0 +1,177 @@
+package log
+
+import (
+	"runtime"
+	"strconv"
+	"strings"
+)
+
+// Suffixes of the keys of the fields that describe an error field.
+const (
+	// errorCausesSuffix is appended to the key of an error field, for the messages of the errors it wraps.
+	errorCausesSuffix = "_causes"
+	// errorFieldsSuffix is appended to the key of an error field, for the fields of the errors
+	// implementing ObjectMarshaler.
+	errorFieldsSuffix = "_fields"
+)
+
+// stacktraceMaxDepth is the maximum number of frames captured in a stack trace.
+const stacktraceMaxDepth = 64
+
+// stacktraceKey is the key of the stack traces added by the Zap and Slog adapters.
+const stacktraceKey = "stacktrace"
+
+// Stacktrace constructs a field with a stack trace captured by the caller, like the stack of a
+// recovered panic (see runtime/debug.Stack), using the key of the stack traces added by the loggers.
+// The entries with this field do not include the stack trace of the code that logged them.
+func Stacktrace(stack string) Field {
+	return String(stacktraceKey, stack)
+}
+
+// hasStacktraceField reports whether the fields include a stack trace, added with Stacktrace.
+func hasStacktraceField(fields []Field) bool {
+	for _, f := range fields {
+		if f.Key == stacktraceKey {
+			return true
+		}
+	}
+	return false
+}
+
+// WithStacktraceLevel sets the minimum level of the entries that include the stack trace of
+// the code that logged them. By default, the stack trace is included in the entries at the
+// Warn level and above in the development profile, and at the Error level and above in the
+// production profile.
+func WithStacktraceLevel(level Level) LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.StacktraceLevel = &level
+		o.DisableStacktrace = false
+	}
+}
+
+// WithoutStacktrace disables the stack traces, for every level.
+func WithoutStacktrace() LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.DisableStacktrace = true
+	}
+}
+
+// stacktraceLevel returns the minimum level of the entries that include a stack trace,
+// and false if stack traces are disabled. When no level is set, it defaults to the level of the profile.
+func stacktraceLevel(profile string, level *Level, disabled bool) (Level, bool) {
+	switch {
+	case disabled:
+		return LevelInfo, false
+	case level != nil:
+		return *level, true
+	case profile == ProfileDevelopment:
+		return LevelWarn, true
+	default:
+		return LevelError, true
+	}
+}
+
+// formatStacktrace formats the stack frames of the program counters, in the same format
+// as the zap adapter: the function name, followed by its file and line, indented by a tab.
+// Like in zap, the runtime.goexit frame at the bottom of every goroutine is omitted.
+func formatStacktrace(pcs []uintptr) string {
+	var sb strings.Builder
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if frame.Function != "" && frame.Function != "runtime.goexit" {
+			if sb.Len() > 0 {
+				sb.WriteByte('\n')
+			}
+			sb.WriteString(frame.Function)
+			sb.WriteString("\n\t")
+			sb.WriteString(frame.File)
+			sb.WriteByte(':')
+			sb.WriteString(strconv.Itoa(frame.Line))
+		}
+		if !more {
+			return sb.String()
+		}
+	}
+}
+
+// ErrorChain returns the error and the errors it wraps, in depth-first order. Both the errors
+// wrapping a single error (like fmt.Errorf with %w) and multiple errors (like errors.Join) are followed.
+func ErrorChain(err error) []error {
+	var chain []error
+	var walk func(err error)
+	walk = func(err error) {
+		if err == nil {
+			return
+		}
+		chain = append(chain, err)
+
+		switch e := err.(type) {
+		case interface{ Unwrap() error }:
+			walk(e.Unwrap())
+		case interface{ Unwrap() []error }:
+			for _, wrapped := range e.Unwrap() {
+				walk(wrapped)
+			}
+		}
+	}
+	walk(err)
+	return chain
+}
+
+// expandErrorFields returns the fields, with the fields describing each error field added after it.
+// The fields are returned as is when there is nothing to add.
+func expandErrorFields(fields []Field) []Field {
+	var expanded []Field
+	for i, f := range fields {
+		details := errorDetails(f)
+		if expanded == nil {
+			if len(details) == 0 {
+				continue
+			}
+			expanded = append(make([]Field, 0, len(fields)+len(details)), fields[:i]...)
+		}
+		expanded = append(expanded, f)
+		expanded = append(expanded, details...)
+	}
+
+	if expanded == nil {
+		return fields
+	}
+	return expanded
+}
+
+// errorDetails returns the fields that describe an error field: the messages of the errors it
+// wraps, under the "<key>_causes" key, and the fields of the errors in the chain implementing
+// ObjectMarshaler, grouped under the "<key>_fields" key.
+func errorDetails(f Field) []Field {
+	if f.Type != ErrorType {
+		return nil
+	}
+	err, ok := f.Interface.(error)
+	if !ok || err == nil {
+		return nil
+	}
+
+	chain := ErrorChain(err)
+
+	var details []Field
+	if len(chain) > 1 {
+		causes := make([]string, len(chain)-1)
+		for i, cause := range chain[1:] {
+			causes[i] = cause.Error()
+		}
+		details = append(details, Any(f.Key+errorCausesSuffix, causes))
+	}
+
+	var errFields []Field
+	for _, e := range chain {
+		if marshaler, ok := e.(ObjectMarshaler); ok {
+			errFields = append(errFields, marshaler.LogFields()...)
+		}
+	}
+	if len(errFields) > 0 {
+		details = append(details, Group(f.Key+errorFieldsSuffix, errFields...))
+	}
+	return details
+}
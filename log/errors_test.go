@@ -0,0 +1,193 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// queryError is an error that describes itself with log fields.
+type queryError struct {
+	table string
+	err   error
+}
+
+func (e *queryError) Error() string {
+	return "query " + e.table + ": " + e.err.Error()
+}
+
+func (e *queryError) Unwrap() error {
+	return e.err
+}
+
+func (e *queryError) LogFields() []log.Field {
+	return []log.Field{log.String("table", e.table)}
+}
+
+func TestErrorChain(t *testing.T) {
+	t.Parallel()
+
+	root := errors.New("connection refused")
+	other := errors.New("timeout")
+	wrapped := fmt.Errorf("ping: %w", root)
+	joined := errors.Join(wrapped, other)
+
+	tests := []struct {
+		name     string
+		err      error
+		expected []error
+	}{
+		{name: "Nil", err: nil, expected: nil},
+		{name: "Single", err: root, expected: []error{root}},
+		{name: "Wrapped", err: wrapped, expected: []error{wrapped, root}},
+		{name: "Joined", err: joined, expected: []error{joined, wrapped, root, other}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, log.ErrorChain(tt.err))
+		})
+	}
+}
+
+func TestErrorFields(t *testing.T) {
+	t.Parallel()
+
+	err := fmt.Errorf("find user: %w", &queryError{table: "users", err: errors.New("no rows")})
+
+	loggers := map[string]func(buf *bytes.Buffer) log.Logger{
+		"Zap": func(buf *bytes.Buffer) log.Logger {
+			return createTestLogger(buf, zapcore.InfoLevel)
+		},
+		"Slog": func(buf *bytes.Buffer) log.Logger {
+			return createTestSlogLogger(buf, slog.LevelInfo)
+		},
+	}
+
+	for name, newLogger := range loggers {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+
+			newLogger(buf).Error(context.Background(), "request failed", log.Err(err))
+
+			var entry map[string]any
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+			assert.Equal(t, "find user: query users: no rows", entry["error"])
+			assert.Equal(t, []any{"query users: no rows", "no rows"}, entry["error_causes"])
+			assert.Equal(t, map[string]any{"table": "users"}, entry["error_fields"])
+		})
+	}
+}
+
+func TestErrorFields_WithoutWrappedErrors(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+
+	createTestLogger(buf, zapcore.InfoLevel).Error(context.Background(), "request failed", log.Err(errors.New("boom")))
+
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+
+	assert.Equal(t, "boom", entry["error"])
+	assert.NotContains(t, entry, "error_causes")
+	assert.NotContains(t, entry, "error_fields")
+}
+
+func TestStacktrace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		opts           []log.LoggerOpt
+		level          log.Level
+		withStacktrace bool
+	}{
+		{name: "ProductionError", level: log.LevelError, withStacktrace: true},
+		{name: "ProductionWarn", level: log.LevelWarn, withStacktrace: false},
+		{name: "DevelopmentWarn", opts: []log.LoggerOpt{log.WithProfile(log.ProfileDevelopment), log.WithFormat(log.FormatJSON)}, level: log.LevelWarn, withStacktrace: true},
+		{name: "CustomLevel", opts: []log.LoggerOpt{log.WithStacktraceLevel(log.LevelInfo)}, level: log.LevelInfo, withStacktrace: true},
+		{name: "Disabled", opts: []log.LoggerOpt{log.WithoutStacktrace()}, level: log.LevelError, withStacktrace: false},
+	}
+
+	for _, adapter := range []string{log.AdapterZap, log.AdapterSlog} {
+		for _, tt := range tests {
+			t.Run(adapter+"_"+tt.name, func(t *testing.T) {
+				t.Parallel()
+				path := filepath.Join(t.TempDir(), "app.log")
+
+				opts := append([]log.LoggerOpt{
+					log.WithAdapter(adapter),
+					log.WithOutput(log.Output{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}),
+				}, tt.opts...)
+				logger, err := log.New(opts...)
+				require.NoError(t, err)
+
+				logger.Log(context.Background(), tt.level, "something happened")
+				require.NoError(t, logger.Sync())
+
+				content, err := os.ReadFile(path)
+				require.NoError(t, err)
+
+				var entry map[string]any
+				require.NoError(t, json.Unmarshal(content, &entry))
+
+				if !tt.withStacktrace {
+					assert.NotContains(t, entry, "stacktrace")
+					return
+				}
+				assert.Contains(t, entry["stacktrace"], "log_test.TestStacktrace")
+			})
+		}
+	}
+}
+
+func TestStacktrace_Field(t *testing.T) {
+	t.Parallel()
+
+	for _, adapter := range []string{log.AdapterZap, log.AdapterSlog} {
+		t.Run(adapter, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "app.log")
+
+			logger, err := log.New(
+				log.WithAdapter(adapter),
+				log.WithOutput(log.Output{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}),
+			)
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			logger.Error(ctx, "panic recovered", log.Stacktrace("goroutine 1 [running]"))
+			assert.Panics(t, func() {
+				logger.Panic(ctx, "panic message", log.Stacktrace("goroutine 2 [running]"))
+			})
+			require.NoError(t, logger.Sync())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			// The stack trace of the field replaces the one of the logger
+			lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+			require.Len(t, lines, 2)
+			for i, line := range lines {
+				assert.Equal(t, 1, strings.Count(line, `"stacktrace"`))
+				assertContainsJSONField(t, line, "stacktrace", fmt.Sprintf("goroutine %d [running]", i+1))
+			}
+		})
+	}
+}
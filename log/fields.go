@@ -1,22 +1,264 @@
 package log
 
 import (
-	"go.uber.org/zap"
+	"fmt"
+	"time"
 )
 
-// Field defines a structured log attribute that allows to add additional information to a log entry in a type-safe way.
-// To avoid reinventing the wheel, this is just a wrapper around slog.Attr, from the slog package. We can changhe the underlying implementation in the future if needed, but the interface for the rest of the application will remain the same.
-type Field = zap.Field
-
-var (
-	Bool     = zap.Bool
-	Int      = zap.Int
-	Int64    = zap.Int64
-	Float64  = zap.Float64
-	String   = zap.String
-	Duration = zap.Duration
-	Float32  = zap.Float32
-	Float64p = zap.Float64p
-	Error    = zap.Error
-	Any      = zap.Any
+// FieldType indicates which member of the Field struct holds the field value.
+type FieldType uint8
+
+// Field types supported by the logger.
+const (
+	// UnknownType is the default field type.
+	UnknownType FieldType = iota
+	// StringType indicates that the field carries a string.
+	StringType
+	// BoolType indicates that the field carries a bool.
+	BoolType
+	// Int64Type indicates that the field carries a signed integer.
+	Int64Type
+	// Uint64Type indicates that the field carries an unsigned integer.
+	Uint64Type
+	// Float64Type indicates that the field carries a float64.
+	Float64Type
+	// Float32Type indicates that the field carries a float32.
+	Float32Type
+	// DurationType indicates that the field carries a time.Duration.
+	DurationType
+	// TimeType indicates that the field carries a time.Time.
+	TimeType
+	// ErrorType indicates that the field carries an error.
+	ErrorType
+	// StringerType indicates that the field carries a fmt.Stringer, resolved when the entry is written.
+	StringerType
+	// GroupType indicates that the field carries a list of nested fields.
+	GroupType
+	// ObjectType indicates that the field carries an ObjectMarshaler.
+	ObjectType
+	// AnyType indicates that the field carries an arbitrary value, encoded by reflection.
+	AnyType
 )
+
+// Field defines a structured log attribute that allows to add additional information to a log entry in a type-safe way.
+// Fields are adapter-neutral: each adapter knows how to encode them into its own representation.
+type Field struct {
+	Key       string
+	Type      FieldType
+	Integer   int64
+	Float     float64
+	String    string
+	Interface any
+}
+
+// ObjectMarshaler is implemented by types that know how to represent themselves as a list of log fields.
+type ObjectMarshaler interface {
+	LogFields() []Field
+}
+
+// Value returns the value carried by the field, as a Go value of the appropriate type.
+// Group and Object fields return their nested fields as a []Field.
+func (f Field) Value() any {
+	switch f.Type {
+	case StringType:
+		return f.String
+	case BoolType:
+		return f.Integer == 1
+	case Int64Type:
+		return f.Integer
+	case Uint64Type:
+		return uint64(f.Integer)
+	case Float64Type:
+		return f.Float
+	case Float32Type:
+		return float32(f.Float)
+	case DurationType:
+		return time.Duration(f.Integer)
+	case StringerType:
+		return stringerValue(f.Interface)
+	case GroupType, ObjectType:
+		return f.Fields()
+	default:
+		return f.Interface
+	}
+}
+
+// Fields returns the nested fields of a Group or Object field. It returns nil for any other field type.
+func (f Field) Fields() []Field {
+	switch f.Type {
+	case GroupType:
+		fields, _ := f.Interface.([]Field)
+		return fields
+	case ObjectType:
+		if obj, ok := f.Interface.(ObjectMarshaler); ok && obj != nil {
+			return obj.LogFields()
+		}
+	}
+	return nil
+}
+
+// String constructs a field with the given key and string value.
+func String(key string, value string) Field {
+	return Field{Key: key, Type: StringType, String: value}
+}
+
+// Bool constructs a field with the given key and bool value.
+func Bool(key string, value bool) Field {
+	var i int64
+	if value {
+		i = 1
+	}
+	return Field{Key: key, Type: BoolType, Integer: i}
+}
+
+// Int constructs a field with the given key and int value.
+func Int(key string, value int) Field {
+	return Int64(key, int64(value))
+}
+
+// Int64 constructs a field with the given key and int64 value.
+func Int64(key string, value int64) Field {
+	return Field{Key: key, Type: Int64Type, Integer: value}
+}
+
+// Uint constructs a field with the given key and uint value.
+func Uint(key string, value uint) Field {
+	return Uint64(key, uint64(value))
+}
+
+// Uint64 constructs a field with the given key and uint64 value.
+func Uint64(key string, value uint64) Field {
+	return Field{Key: key, Type: Uint64Type, Integer: int64(value)}
+}
+
+// Float64 constructs a field with the given key and float64 value.
+func Float64(key string, value float64) Field {
+	return Field{Key: key, Type: Float64Type, Float: value}
+}
+
+// Float64p constructs a field that carries a *float64. A nil pointer is logged as null.
+func Float64p(key string, value *float64) Field {
+	if value == nil {
+		return Any(key, nil)
+	}
+	return Float64(key, *value)
+}
+
+// Float32 constructs a field with the given key and float32 value.
+func Float32(key string, value float32) Field {
+	return Field{Key: key, Type: Float32Type, Float: float64(value)}
+}
+
+// Duration constructs a field with the given key and time.Duration value.
+func Duration(key string, value time.Duration) Field {
+	return Field{Key: key, Type: DurationType, Integer: int64(value)}
+}
+
+// Time constructs a field with the given key and time.Time value.
+func Time(key string, value time.Time) Field {
+	return Field{Key: key, Type: TimeType, Interface: value}
+}
+
+// Err constructs a field that carries an error, using "error" as the key.
+func Err(err error) Field {
+	return NamedErr("error", err)
+}
+
+// Error is an alias of Err.
+//
+// Deprecated: use Err instead.
+func Error(err error) Field {
+	return Err(err)
+}
+
+// NamedErr constructs a field that carries an error under the given key.
+// Nil errors are omitted from the log output.
+func NamedErr(key string, err error) Field {
+	return Field{Key: key, Type: ErrorType, Interface: err}
+}
+
+// Stringer constructs a field with the given key and the output of the value's String method.
+// The String method is only called when the entry is written.
+func Stringer(key string, value fmt.Stringer) Field {
+	return Field{Key: key, Type: StringerType, Interface: value}
+}
+
+// Group constructs a field that nests the given fields under the provided key.
+func Group(key string, fields ...Field) Field {
+	return Field{Key: key, Type: GroupType, Interface: fields}
+}
+
+// Object constructs a field that nests the fields returned by the ObjectMarshaler under the provided key.
+// The LogFields method is only called when the entry is written.
+func Object(key string, value ObjectMarshaler) Field {
+	return Field{Key: key, Type: ObjectType, Interface: value}
+}
+
+// Any constructs a field with the given key and an arbitrary value, choosing the best
+// typed representation for it. Values of unknown types are encoded by reflection.
+func Any(key string, value any) Field {
+	switch v := value.(type) {
+	case Field:
+		return Group(key, v)
+	case []Field:
+		return Group(key, v...)
+	case string:
+		return String(key, v)
+	case bool:
+		return Bool(key, v)
+	case int:
+		return Int(key, v)
+	case int8:
+		return Int64(key, int64(v))
+	case int16:
+		return Int64(key, int64(v))
+	case int32:
+		return Int64(key, int64(v))
+	case int64:
+		return Int64(key, v)
+	case uint:
+		return Uint(key, v)
+	case uint8:
+		return Uint64(key, uint64(v))
+	case uint16:
+		return Uint64(key, uint64(v))
+	case uint32:
+		return Uint64(key, uint64(v))
+	case uint64:
+		return Uint64(key, v)
+	case float32:
+		return Float32(key, v)
+	case float64:
+		return Float64(key, v)
+	case *float64:
+		return Float64p(key, v)
+	case time.Duration:
+		return Duration(key, v)
+	case time.Time:
+		return Time(key, v)
+	case error:
+		return NamedErr(key, v)
+	case ObjectMarshaler:
+		return Object(key, v)
+	case fmt.Stringer:
+		return Stringer(key, v)
+	default:
+		return Field{Key: key, Type: AnyType, Interface: v}
+	}
+}
+
+// stringerValue safely resolves the output of a fmt.Stringer, guarding against nil receivers
+// whose String method panics.
+func stringerValue(v any) (s string) {
+	stringer, ok := v.(fmt.Stringer)
+	if !ok || stringer == nil {
+		return "<nil>"
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			s = "<nil>"
+		}
+	}()
+	return stringer.String()
+}
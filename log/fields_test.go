@@ -0,0 +1,137 @@
+package log_test
+
+import (
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+type testObject struct {
+	ID   int
+	Name string
+}
+
+func (o testObject) LogFields() []log.Field {
+	return []log.Field{
+		log.Int("id", o.ID),
+		log.String("name", o.Name),
+	}
+}
+
+func TestFieldConstructors(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+	err := errors.New("boom")
+	pi := 3.14
+	u := &url.URL{Scheme: "https", Host: "example.com"}
+
+	tests := []struct {
+		name         string
+		field        log.Field
+		expectedType log.FieldType
+		expected     any
+	}{
+		{name: "String", field: log.String("key", "value"), expectedType: log.StringType, expected: "value"},
+		{name: "Bool", field: log.Bool("key", true), expectedType: log.BoolType, expected: true},
+		{name: "Int", field: log.Int("key", 10), expectedType: log.Int64Type, expected: int64(10)},
+		{name: "Int64", field: log.Int64("key", -10), expectedType: log.Int64Type, expected: int64(-10)},
+		{name: "Uint", field: log.Uint("key", 10), expectedType: log.Uint64Type, expected: uint64(10)},
+		{name: "Uint64", field: log.Uint64("key", 10), expectedType: log.Uint64Type, expected: uint64(10)},
+		{name: "Float64", field: log.Float64("key", 1.5), expectedType: log.Float64Type, expected: 1.5},
+		{name: "Float64p", field: log.Float64p("key", &pi), expectedType: log.Float64Type, expected: pi},
+		{name: "Float64pNil", field: log.Float64p("key", nil), expectedType: log.AnyType, expected: nil},
+		{name: "Float32", field: log.Float32("key", 1.5), expectedType: log.Float32Type, expected: float32(1.5)},
+		{name: "Duration", field: log.Duration("key", time.Second), expectedType: log.DurationType, expected: time.Second},
+		{name: "Time", field: log.Time("key", now), expectedType: log.TimeType, expected: now},
+		{name: "NamedErr", field: log.NamedErr("key", err), expectedType: log.ErrorType, expected: err},
+		{name: "Stringer", field: log.Stringer("key", u), expectedType: log.StringerType, expected: "https://example.com"},
+		{name: "Any", field: log.Any("key", map[string]int{"a": 1}), expectedType: log.AnyType, expected: map[string]int{"a": 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, "key", tt.field.Key)
+			assert.Equal(t, tt.expectedType, tt.field.Type)
+			assert.Equal(t, tt.expected, tt.field.Value())
+		})
+	}
+}
+
+func TestErr(t *testing.T) {
+	t.Parallel()
+
+	err := errors.New("boom")
+	field := log.Err(err)
+
+	assert.Equal(t, "error", field.Key)
+	assert.Equal(t, log.ErrorType, field.Type)
+	assert.Equal(t, err, field.Value())
+}
+
+func TestGroup(t *testing.T) {
+	t.Parallel()
+
+	field := log.Group("request", log.String("method", "GET"), log.Int("status", 200))
+
+	assert.Equal(t, log.GroupType, field.Type)
+	assert.Len(t, field.Fields(), 2)
+	assert.Equal(t, "method", field.Fields()[0].Key)
+	assert.Equal(t, field.Fields(), field.Value())
+}
+
+func TestObject(t *testing.T) {
+	t.Parallel()
+
+	field := log.Object("user", testObject{ID: 1, Name: "john"})
+
+	assert.Equal(t, log.ObjectType, field.Type)
+	assert.Equal(t, []log.Field{log.Int("id", 1), log.String("name", "john")}, field.Fields())
+}
+
+func TestStringer_NilValue(t *testing.T) {
+	t.Parallel()
+
+	var u *url.URL
+	field := log.Stringer("url", u)
+
+	assert.Equal(t, "<nil>", field.Value())
+}
+
+func TestAny(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		value        any
+		expectedType log.FieldType
+	}{
+		{name: "String", value: "value", expectedType: log.StringType},
+		{name: "Bool", value: true, expectedType: log.BoolType},
+		{name: "Int", value: 1, expectedType: log.Int64Type},
+		{name: "Int32", value: int32(1), expectedType: log.Int64Type},
+		{name: "Uint8", value: uint8(1), expectedType: log.Uint64Type},
+		{name: "Float32", value: float32(1), expectedType: log.Float32Type},
+		{name: "Float64", value: float64(1), expectedType: log.Float64Type},
+		{name: "Duration", value: time.Second, expectedType: log.DurationType},
+		{name: "Time", value: time.Now(), expectedType: log.TimeType},
+		{name: "Error", value: errors.New("boom"), expectedType: log.ErrorType},
+		{name: "Object", value: testObject{}, expectedType: log.ObjectType},
+		{name: "Stringer", value: &url.URL{}, expectedType: log.StringerType},
+		{name: "Fields", value: []log.Field{log.String("a", "b")}, expectedType: log.GroupType},
+		{name: "Other", value: []int{1, 2}, expectedType: log.AnyType},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expectedType, log.Any("key", tt.value).Type)
+		})
+	}
+}
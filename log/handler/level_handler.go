@@ -1,8 +1,12 @@
 package handler
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/brpaz/lib-go/log"
 )
@@ -10,11 +14,42 @@ import (
 // SetLogLevelRequest is the structure that will be used to parse the incoming JSON request.
 type SetLogLevelRequest struct {
 	Level string `json:"level"`
+	// Logger is the name of a named logger, created with log.Logger.Named, whose level should be changed.
+	// When empty, the level of the logger passed to the handler is changed.
+	Logger string `json:"logger,omitempty"`
+	// Duration is an optional duration (like "10m"), after which the previous level is restored.
+	Duration string `json:"duration,omitempty"`
+}
+
+// LogLevelResponse is the structure returned when getting the current log level.
+type LogLevelResponse struct {
+	Level  string `json:"level"`
+	Logger string `json:"logger,omitempty"`
+	// RevertLevel and RevertAt are set when the level is temporarily overridden,
+	// and hold the level that will be restored and when.
+	RevertLevel string     `json:"revert_level,omitempty"`
+	RevertAt    *time.Time `json:"revert_at,omitempty"`
 }
 
 // LevelHandler is an HTTP handler that dynamically changes the log level of the logger.
+// The level of a single component can be changed by sending the name of its named logger,
+// for example {"level": "debug", "logger": "db"}.
+//
+// When a duration is sent, like {"level": "debug", "duration": "10m"}, the previous level is
+// restored automatically once the duration elapses. GET requests return the current level and
+// any pending revert, for the logger passed to the handler or the one in the "logger" query parameter.
 func LevelHandler(logger log.Logger) http.HandlerFunc {
+	overrides := &levelOverrides{
+		logger:  logger,
+		pending: make(map[string]*levelOverride),
+	}
+
 	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			overrides.handleGet(w, r)
+			return
+		}
+
 		ctx := r.Context()
 
 		// Ensure the content type is application/json
@@ -31,7 +66,14 @@ func LevelHandler(logger log.Logger) http.HandlerFunc {
 		}
 
 		// Log the request to change log level
-		logger.Info(ctx, "Received log level change request", log.String("level", req.Level))
+		fields := []log.Field{log.String("level", req.Level)}
+		if req.Logger != "" {
+			fields = append(fields, log.String("target_logger", req.Logger))
+		}
+		if req.Duration != "" {
+			fields = append(fields, log.String("duration", req.Duration))
+		}
+		logger.Info(ctx, "Received log level change request", fields...)
 
 		// Validate the log level string and convert it to a Level type
 		level, err := log.LevelFromString(req.Level)
@@ -40,16 +82,148 @@ func LevelHandler(logger log.Logger) http.HandlerFunc {
 			return
 		}
 
+		var duration time.Duration
+		if req.Duration != "" {
+			duration, err = time.ParseDuration(req.Duration)
+			if err != nil || duration <= 0 {
+				http.Error(w, "Invalid duration: must be a positive duration, like 10m", http.StatusBadRequest)
+				return
+			}
+		}
+
 		// Attempt to set the new log level
-		if err := logger.SetLevel(level); err != nil {
+		if err := overrides.set(req.Logger, level, duration); err != nil {
+			if errors.Is(err, log.ErrUnknownLogger) {
+				http.Error(w, "Unknown logger: "+err.Error(), http.StatusNotFound)
+				return
+			}
 			http.Error(w, "Failed to set log level: "+err.Error(), http.StatusInternalServerError)
 			return
 		}
 
 		// Log the success
-		logger.Info(ctx, "Log level changed successfully", log.String("level", req.Level))
+		logger.Info(ctx, "Log level changed successfully", fields...)
 
 		// Respond with no content, since there's no response body
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+// levelOverrides keeps track of the temporary level overrides, by logger name.
+type levelOverrides struct {
+	logger  log.Logger
+	mu      sync.Mutex
+	pending map[string]*levelOverride
+}
+
+// levelOverride is a temporary level override, reverted when the timer fires.
+type levelOverride struct {
+	timer    *time.Timer
+	previous log.Level
+	revertAt time.Time
+}
+
+// handleGet writes the current level and any pending revert of the requested logger.
+func (o *levelOverrides) handleGet(w http.ResponseWriter, r *http.Request) {
+	name := r.URL.Query().Get("logger")
+
+	o.mu.Lock()
+	level, err := getLevel(o.logger, name)
+	override := o.pending[name]
+	o.mu.Unlock()
+
+	if err != nil {
+		http.Error(w, "Unknown logger: "+err.Error(), http.StatusNotFound)
+		return
+	}
+
+	resp := LogLevelResponse{
+		Level:  level.String(),
+		Logger: name,
+	}
+	if override != nil {
+		revertAt := override.revertAt
+		resp.RevertLevel = override.previous.String()
+		resp.RevertAt = &revertAt
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// set updates the level of the logger with the given name. When the duration is positive,
+// the level that was set before is restored after the duration. Setting the level again
+// cancels the pending revert, while keeping the level that will be restored.
+func (o *levelOverrides) set(name string, level log.Level, duration time.Duration) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	previous, err := getLevel(o.logger, name)
+	if err != nil {
+		return err
+	}
+
+	pending := o.pending[name]
+	if pending != nil {
+		previous = pending.previous
+	}
+
+	if err := setLevel(o.logger, name, level); err != nil {
+		return err
+	}
+
+	if pending != nil {
+		pending.timer.Stop()
+		delete(o.pending, name)
+	}
+
+	if duration > 0 {
+		override := &levelOverride{
+			previous: previous,
+			revertAt: time.Now().Add(duration),
+		}
+		override.timer = time.AfterFunc(duration, func() {
+			o.revert(name, override)
+		})
+		o.pending[name] = override
+	}
+	return nil
+}
+
+// revert restores the previous level of the logger, unless the override was replaced in the meantime.
+func (o *levelOverrides) revert(name string, override *levelOverride) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.pending[name] != override {
+		return
+	}
+	delete(o.pending, name)
+
+	fields := []log.Field{log.String("level", override.previous.String())}
+	if name != "" {
+		fields = append(fields, log.String("target_logger", name))
+	}
+
+	if err := setLevel(o.logger, name, override.previous); err != nil {
+		o.logger.Error(context.Background(), "Failed to revert log level", append(fields, log.Err(err))...)
+		return
+	}
+	o.logger.Info(context.Background(), "Log level reverted", fields...)
+}
+
+// getLevel returns the level of the logger, or of its named logger with the given name.
+func getLevel(logger log.Logger, name string) (log.Level, error) {
+	if name == "" {
+		return logger.GetLevel(), nil
+	}
+	return log.RegistryOf(logger).GetLevel(name)
+}
+
+// setLevel sets the level of the logger, or of its named logger with the given name.
+func setLevel(logger log.Logger, name string, level log.Level) error {
+	if name == "" {
+		return logger.SetLevel(level)
+	}
+	return log.RegistryOf(logger).SetLevel(name, level)
+}
@@ -6,8 +6,10 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/brpaz/lib-go/log"
 	h "github.com/brpaz/lib-go/log/handler"
@@ -15,9 +17,14 @@ import (
 
 // Helper function to create a new HTTP request for the log level handler
 func createLogLevelRequest(level string) (*http.Request, *httptest.ResponseRecorder, *log.InMemoryLogger) {
+	return createNamedLogLevelRequest(level, "")
+}
+
+// Helper function to create a new HTTP request changing the level of a named logger
+func createNamedLogLevelRequest(level, name string) (*http.Request, *httptest.ResponseRecorder, *log.InMemoryLogger) {
 	logger := log.NewInMemory(log.LevelInfo)
 
-	reqBody := h.SetLogLevelRequest{Level: level}
+	reqBody := h.SetLogLevelRequest{Level: level, Logger: name}
 	body, err := json.Marshal(reqBody)
 	if err != nil {
 		panic("Failed to marshal request body") // Fail fast if marshalling fails
@@ -47,6 +54,40 @@ func TestLogLevelHandler(t *testing.T) {
 		assert.Equal(t, log.LevelError, logger.GetLevel())
 	})
 
+	t.Run("With Trace Log Level", func(t *testing.T) {
+		req, rr, logger := createLogLevelRequest("trace")
+
+		handler := h.LevelHandler(logger)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, log.LevelTrace, logger.GetLevel())
+	})
+
+	t.Run("With Named Logger", func(t *testing.T) {
+		req, rr, logger := createNamedLogLevelRequest("debug", "db")
+		dbLogger := logger.Named("db")
+		httpLogger := logger.Named("http")
+
+		handler := h.LevelHandler(logger)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, log.LevelDebug, dbLogger.GetLevel())
+		assert.Equal(t, log.LevelInfo, httpLogger.GetLevel())
+		assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	})
+
+	t.Run("With Unknown Named Logger", func(t *testing.T) {
+		req, rr, logger := createNamedLogLevelRequest("debug", "unknown")
+
+		handler := h.LevelHandler(logger)
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	})
+
 	t.Run("With Invalid Log Level", func(t *testing.T) {
 		req, rr, logger := createLogLevelRequest("invalid")
 
@@ -83,3 +124,129 @@ func TestLogLevelHandler(t *testing.T) {
 		assert.Equal(t, log.LevelInfo, logger.GetLevel())
 	})
 }
+
+// Helper function to send a request changing the log level to the handler
+func setLogLevel(t *testing.T, handler http.Handler, req h.SetLogLevelRequest) *httptest.ResponseRecorder {
+	body, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodPost, "/log-level", bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, r)
+	return rr
+}
+
+// Helper function to get the current log level from the handler
+func getLogLevel(t *testing.T, handler http.Handler, target string) h.LogLevelResponse {
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var resp h.LogLevelResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&resp))
+	return resp
+}
+
+func TestLogLevelHandler_TemporaryOverride(t *testing.T) {
+	t.Run("Reverts After Duration", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelInfo)
+		handler := h.LevelHandler(logger)
+
+		rr := setLogLevel(t, handler, h.SetLogLevelRequest{Level: "debug", Duration: "50ms"})
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, log.LevelDebug, logger.GetLevel())
+
+		assert.Eventually(t, func() bool {
+			return logger.GetLevel() == log.LevelInfo
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Keeps Original Level When Overridden Again", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelInfo)
+		handler := h.LevelHandler(logger)
+
+		require.Equal(t, http.StatusNoContent, setLogLevel(t, handler, h.SetLogLevelRequest{Level: "debug", Duration: "1h"}).Code)
+		require.Equal(t, http.StatusNoContent, setLogLevel(t, handler, h.SetLogLevelRequest{Level: "trace", Duration: "1h"}).Code)
+
+		resp := getLogLevel(t, handler, "/log-level")
+		assert.Equal(t, "trace", resp.Level)
+		assert.Equal(t, "info", resp.RevertLevel)
+	})
+
+	t.Run("Permanent Change Cancels Revert", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelInfo)
+		handler := h.LevelHandler(logger)
+
+		require.Equal(t, http.StatusNoContent, setLogLevel(t, handler, h.SetLogLevelRequest{Level: "debug", Duration: "20ms"}).Code)
+		require.Equal(t, http.StatusNoContent, setLogLevel(t, handler, h.SetLogLevelRequest{Level: "warn"}).Code)
+
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, log.LevelWarn, logger.GetLevel())
+		assert.Nil(t, getLogLevel(t, handler, "/log-level").RevertAt)
+	})
+
+	t.Run("Named Logger", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelInfo)
+		dbLogger := logger.Named("db")
+		handler := h.LevelHandler(logger)
+
+		rr := setLogLevel(t, handler, h.SetLogLevelRequest{Level: "debug", Logger: "db", Duration: "50ms"})
+		require.Equal(t, http.StatusNoContent, rr.Code)
+		assert.Equal(t, log.LevelDebug, dbLogger.GetLevel())
+
+		assert.Eventually(t, func() bool {
+			return dbLogger.GetLevel() == log.LevelInfo
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Invalid Duration", func(t *testing.T) {
+		for _, duration := range []string{"invalid", "-10m", "0s"} {
+			logger := log.NewInMemory(log.LevelInfo)
+			rr := setLogLevel(t, h.LevelHandler(logger), h.SetLogLevelRequest{Level: "debug", Duration: duration})
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, duration)
+			assert.Equal(t, log.LevelInfo, logger.GetLevel())
+		}
+	})
+}
+
+func TestLogLevelHandler_Get(t *testing.T) {
+	t.Run("Current Level", func(t *testing.T) {
+		handler := h.LevelHandler(log.NewInMemory(log.LevelWarn))
+
+		resp := getLogLevel(t, handler, "/log-level")
+
+		assert.Equal(t, h.LogLevelResponse{Level: "warn"}, resp)
+	})
+
+	t.Run("Pending Revert", func(t *testing.T) {
+		handler := h.LevelHandler(log.NewInMemory(log.LevelInfo))
+		before := time.Now()
+
+		require.Equal(t, http.StatusNoContent, setLogLevel(t, handler, h.SetLogLevelRequest{Level: "debug", Duration: "10m"}).Code)
+		resp := getLogLevel(t, handler, "/log-level")
+
+		assert.Equal(t, "debug", resp.Level)
+		assert.Equal(t, "info", resp.RevertLevel)
+		require.NotNil(t, resp.RevertAt)
+		assert.WithinDuration(t, before.Add(10*time.Minute), *resp.RevertAt, time.Second)
+	})
+
+	t.Run("Named Logger", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelInfo)
+		require.NoError(t, logger.Named("db").SetLevel(log.LevelDebug))
+
+		resp := getLogLevel(t, h.LevelHandler(logger), "/log-level?logger=db")
+
+		assert.Equal(t, h.LogLevelResponse{Level: "debug", Logger: "db"}, resp)
+	})
+
+	t.Run("Unknown Named Logger", func(t *testing.T) {
+		rr := httptest.NewRecorder()
+		h.LevelHandler(log.NewInMemory(log.LevelInfo)).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/log-level?logger=db", nil))
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+}
@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brpaz/lib-go/httputil"
+	"github.com/brpaz/lib-go/log"
+)
+
+const (
+	// defaultLogsLimit is the number of entries returned when the limit parameter is not provided.
+	defaultLogsLimit = 100
+	// logsStreamBuffer is the number of entries buffered for each stream, before entries are dropped.
+	logsStreamBuffer = 256
+)
+
+// LogEntryResponse is the JSON representation of a log entry returned by the LogsHandler.
+type LogEntryResponse struct {
+	Timestamp time.Time      `json:"ts"`
+	Level     string         `json:"level"`
+	Message   string         `json:"msg"`
+	Logger    string         `json:"logger,omitempty"`
+	Fields    map[string]any `json:"fields,omitempty"`
+}
+
+// LogsHandler is an HTTP handler that returns the recent entries of a ring buffer logger.
+//
+// By default, it returns the last entries as a JSON array. When the request accepts
+// "text/event-stream" or has the "stream=true" query parameter, new entries are streamed
+// as Server-Sent Events until the client disconnects.
+//
+// The entries can be filtered with the following query parameters:
+//   - limit: the maximum number of entries to return (100 by default, ignored when streaming).
+//   - level: the minimum level of the entries, like "warn".
+//   - logger: the name of the named logger that created the entries.
+//   - field: a field the entries must have, like "field=user_id:42". It can be repeated.
+func LogsHandler(logger *log.RingBufferLogger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+
+		matchers, err := logsMatchers(query.Get("level"), query.Get("logger"), query["field"])
+		if err != nil {
+			http.Error(w, "Invalid filter: "+err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		if query.Get("stream") == "true" || strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+			streamLogs(w, r, logger, matchers)
+			return
+		}
+
+		limit := defaultLogsLimit
+		if value := query.Get("limit"); value != "" {
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit <= 0 {
+				http.Error(w, "Invalid limit: must be a positive integer", http.StatusBadRequest)
+				return
+			}
+		}
+
+		entries := logger.Last(limit, matchers...)
+		resp := make([]LogEntryResponse, len(entries))
+		for i, entry := range entries {
+			resp[i] = toLogEntryResponse(entry)
+		}
+
+		httputil.JSON(w, http.StatusOK, resp)
+	}
+}
+
+// streamLogs writes the new entries matching all the matchers as Server-Sent Events.
+func streamLogs(w http.ResponseWriter, r *http.Request, logger *log.RingBufferLogger, matchers []log.EntryMatcher) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	entries, cancel := logger.Subscribe(logsStreamBuffer)
+	defer cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	match := log.MatchAll(matchers...)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case entry, ok := <-entries:
+			if !ok {
+				return
+			}
+			if !match(entry) {
+				continue
+			}
+
+			data, err := json.Marshal(toLogEntryResponse(entry))
+			if err != nil {
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
+// logsMatchers builds the entry matchers from the query parameters.
+func logsMatchers(level, name string, fields []string) ([]log.EntryMatcher, error) {
+	var matchers []log.EntryMatcher
+
+	if level != "" {
+		lvl, err := log.LevelFromString(level)
+		if err != nil {
+			return nil, fmt.Errorf("invalid level: %w", err)
+		}
+		matchers = append(matchers, log.MatchMinLevel(lvl))
+	}
+
+	if name != "" {
+		matchers = append(matchers, log.MatchLogger(name))
+	}
+
+	for _, field := range fields {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok || key == "" {
+			return nil, fmt.Errorf("field %q must be in the key:value format", field)
+		}
+		matchers = append(matchers, matchFieldString(key, value))
+	}
+
+	return matchers, nil
+}
+
+// matchFieldString matches the entries with a field whose value, formatted as a string, is equal to the given value.
+func matchFieldString(key, value string) log.EntryMatcher {
+	return func(entry log.InMemoryLogEntry) bool {
+		f, ok := entry.GetField(key)
+		return ok && fmt.Sprint(f.Value()) == value
+	}
+}
+
+// toLogEntryResponse converts a log entry to its JSON representation.
+func toLogEntryResponse(entry log.InMemoryLogEntry) LogEntryResponse {
+	return LogEntryResponse{
+		Timestamp: entry.Timestamp,
+		Level:     entry.Level,
+		Message:   entry.Message,
+		Logger:    entry.Logger,
+		Fields:    fieldsToJSON(entry.Fields),
+	}
+}
+
+// fieldsToJSON converts the fields to a map of values that can be encoded as JSON.
+func fieldsToJSON(fields []log.Field) map[string]any {
+	if len(fields) == 0 {
+		return nil
+	}
+
+	values := make(map[string]any, len(fields))
+	for _, f := range fields {
+		switch f.Type {
+		case log.ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				values[f.Key] = err.Error()
+			}
+		case log.Float64Type, log.Float32Type:
+			// NaN and infinite values can't be encoded as JSON numbers
+			if math.IsNaN(f.Float) || math.IsInf(f.Float, 0) {
+				values[f.Key] = strconv.FormatFloat(f.Float, 'g', -1, 64)
+				continue
+			}
+			values[f.Key] = f.Value()
+		case log.DurationType:
+			values[f.Key] = fmt.Sprint(f.Value())
+		case log.GroupType, log.ObjectType:
+			values[f.Key] = fieldsToJSON(f.Fields())
+		case log.AnyType:
+			// Values that can't be encoded as JSON are formatted as strings.
+			if _, err := json.Marshal(f.Interface); err != nil {
+				values[f.Key] = fmt.Sprint(f.Interface)
+				continue
+			}
+			values[f.Key] = f.Interface
+		default:
+			values[f.Key] = f.Value()
+		}
+	}
+	return values
+}
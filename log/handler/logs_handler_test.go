@@ -0,0 +1,142 @@
+package handler_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+	h "github.com/brpaz/lib-go/log/handler"
+)
+
+// Helper function to create a ring buffer logger with some entries
+func createRingBufferLogger() *log.RingBufferLogger {
+	logger := log.NewRingBuffer(log.LevelDebug, 10)
+	ctx := context.Background()
+
+	logger.Debug(ctx, "debug message")
+	logger.Info(ctx, "request handled", log.Int("status", 200), log.Duration("elapsed", time.Second))
+	logger.Named("db").Error(ctx, "query failed", log.Err(errors.New("timeout")), log.Group("query", log.String("table", "users")))
+	logger.Warn(ctx, "request failed", log.Int("status", 500))
+	return logger
+}
+
+// Helper function to get the entries returned by the logs handler
+func getLogs(t *testing.T, logger *log.RingBufferLogger, target string) []h.LogEntryResponse {
+	rr := httptest.NewRecorder()
+	h.LogsHandler(logger).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+
+	var entries []h.LogEntryResponse
+	require.NoError(t, json.NewDecoder(rr.Body).Decode(&entries))
+	return entries
+}
+
+func TestLogsHandler(t *testing.T) {
+	logger := createRingBufferLogger()
+
+	t.Run("All Entries", func(t *testing.T) {
+		entries := getLogs(t, logger, "/logs")
+
+		require.Len(t, entries, 4)
+		assert.Equal(t, "debug message", entries[0].Message)
+		assert.Equal(t, "request handled", entries[1].Message)
+		assert.Equal(t, map[string]any{"status": float64(200), "elapsed": "1s"}, entries[1].Fields)
+		assert.Equal(t, "db", entries[2].Logger)
+		assert.Equal(t, map[string]any{"error": "timeout", "query": map[string]any{"table": "users"}}, entries[2].Fields)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		entries := getLogs(t, logger, "/logs?limit=1")
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, "request failed", entries[0].Message)
+	})
+
+	t.Run("Filter By Level", func(t *testing.T) {
+		entries := getLogs(t, logger, "/logs?level=warn")
+
+		require.Len(t, entries, 2)
+		assert.Equal(t, "error", entries[0].Level)
+		assert.Equal(t, "warn", entries[1].Level)
+	})
+
+	t.Run("Filter By Field", func(t *testing.T) {
+		entries := getLogs(t, logger, "/logs?field=status:500")
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, "request failed", entries[0].Message)
+	})
+
+	t.Run("Filter By Logger", func(t *testing.T) {
+		entries := getLogs(t, logger, "/logs?logger=db")
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, "query failed", entries[0].Message)
+	})
+
+	t.Run("Non-Finite Floats", func(t *testing.T) {
+		logger := log.NewRingBuffer(log.LevelDebug, 10)
+		logger.Info(context.Background(), "ratio computed", log.Float64("ratio", math.NaN()), log.Float64("max", math.Inf(1)))
+
+		entries := getLogs(t, logger, "/logs")
+
+		require.Len(t, entries, 1)
+		assert.Equal(t, map[string]any{"ratio": "NaN", "max": "+Inf"}, entries[0].Fields)
+	})
+
+	t.Run("Invalid Parameters", func(t *testing.T) {
+		for _, target := range []string{"/logs?limit=0", "/logs?limit=abc", "/logs?level=invalid", "/logs?field=status"} {
+			rr := httptest.NewRecorder()
+			h.LogsHandler(logger).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, target, nil))
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, target)
+		}
+	})
+}
+
+func TestLogsHandler_Stream(t *testing.T) {
+	logger := log.NewRingBuffer(log.LevelDebug, 10)
+	server := httptest.NewServer(h.LogsHandler(logger))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"?level=warn", nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The headers are sent once the handler subscribed to the logger.
+	logger.Info(context.Background(), "filtered message")
+	logger.Error(context.Background(), "streamed message", log.String("key", "value"))
+
+	reader := bufio.NewReader(resp.Body)
+	line, err := reader.ReadString('\n')
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(line, "data: "), line)
+
+	var entry h.LogEntryResponse
+	require.NoError(t, json.Unmarshal([]byte(strings.TrimPrefix(line, "data: ")), &entry))
+	assert.Equal(t, "streamed message", entry.Message)
+	assert.Equal(t, "error", entry.Level)
+	assert.Equal(t, map[string]any{"key": "value"}, entry.Fields)
+}
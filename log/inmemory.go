@@ -2,14 +2,25 @@ package log
 
 import (
 	"context"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
 // InMemoryLogger stores log entries in memory for testing purposes.
+// It is safe for concurrent use, and the entries are shared by the loggers created
+// with With and Named, so they can be queried from the logger the test created.
 type InMemoryLogger struct {
-	level   Level
-	fields  []Field
-	entries []InMemoryLogEntry
+	level       *levelVar
+	name        string
+	fields      []Field
+	store       *inMemoryStore
+	registry    *LevelRegistry
+	exitOnFatal bool
+	extractors  []ContextExtractor
 }
 
 // InMemoryLogEntry represents a single log entry.
@@ -18,6 +29,8 @@ type InMemoryLogEntry struct {
 	Message   string
 	Level     string
 	Fields    []Field
+	// Logger is the name of the logger that created the entry, set for named loggers.
+	Logger string
 }
 
 // GetField retrieves a field by its key from the log entry.
@@ -30,6 +43,172 @@ func (e *InMemoryLogEntry) GetField(key string) (Field, bool) {
 	return Field{}, false
 }
 
+// EntryMatcher reports whether a log entry matches a condition.
+// It is used to query the entries of the InMemoryLogger.
+type EntryMatcher func(entry InMemoryLogEntry) bool
+
+// MatchLevel matches the entries logged with the given level.
+func MatchLevel(level Level) EntryMatcher {
+	return func(entry InMemoryLogEntry) bool {
+		return entry.Level == level.String()
+	}
+}
+
+// MatchMinLevel matches the entries logged with the given level or a more severe one.
+func MatchMinLevel(level Level) EntryMatcher {
+	return func(entry InMemoryLogEntry) bool {
+		lvl, err := LevelFromString(entry.Level)
+		return err == nil && lvl >= level
+	}
+}
+
+// MatchMessage matches the entries with the given message.
+func MatchMessage(msg string) EntryMatcher {
+	return func(entry InMemoryLogEntry) bool {
+		return entry.Message == msg
+	}
+}
+
+// MatchMessageContains matches the entries whose message contains the given text.
+func MatchMessageContains(text string) EntryMatcher {
+	return func(entry InMemoryLogEntry) bool {
+		return strings.Contains(entry.Message, text)
+	}
+}
+
+// MatchField matches the entries with a field with the given key and value.
+// The value is compared to the value of the field created with Any, so MatchField("status", 200)
+// matches a field created with Int("status", 200).
+func MatchField(key string, value any) EntryMatcher {
+	expected := Any(key, value).Value()
+	return func(entry InMemoryLogEntry) bool {
+		f, ok := entry.GetField(key)
+		return ok && reflect.DeepEqual(f.Value(), expected)
+	}
+}
+
+// MatchLogger matches the entries created by the named logger with the given name.
+func MatchLogger(name string) EntryMatcher {
+	return func(entry InMemoryLogEntry) bool {
+		return entry.Logger == name
+	}
+}
+
+// MatchAll matches the entries that match all the matchers.
+func MatchAll(matchers ...EntryMatcher) EntryMatcher {
+	return func(entry InMemoryLogEntry) bool {
+		return matchesAll(entry, matchers)
+	}
+}
+
+// matchesAll reports whether the entry matches all the matchers.
+func matchesAll(entry InMemoryLogEntry, matchers []EntryMatcher) bool {
+	for _, match := range matchers {
+		if !match(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// inMemoryStore holds the entries of an InMemoryLogger and its child loggers.
+// When the capacity is positive, the store keeps only the most recent entries, in a ring buffer.
+type inMemoryStore struct {
+	mu       sync.Mutex
+	entries  []InMemoryLogEntry
+	capacity int
+	// start is the index of the oldest entry, once a bounded store is full.
+	start int
+	// added is closed and cleared whenever an entry is added, to wake up WaitFor.
+	added       chan struct{}
+	subscribers map[chan InMemoryLogEntry]struct{}
+}
+
+// newInMemoryStore creates an empty store. A positive capacity bounds the number of entries.
+func newInMemoryStore(capacity int) *inMemoryStore {
+	s := &inMemoryStore{
+		capacity: capacity,
+	}
+	if capacity > 0 {
+		s.entries = make([]InMemoryLogEntry, 0, capacity)
+	}
+	return s
+}
+
+// add appends an entry, replacing the oldest one if the store is full, and notifies
+// the goroutines waiting for new entries and the subscribers.
+func (s *inMemoryStore) add(entry InMemoryLogEntry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.capacity > 0 && len(s.entries) == s.capacity {
+		s.entries[s.start] = entry
+		s.start = (s.start + 1) % s.capacity
+	} else {
+		s.entries = append(s.entries, entry)
+	}
+
+	if s.added != nil {
+		close(s.added)
+		s.added = nil
+	}
+
+	// Slow subscribers miss entries, instead of blocking the loggers.
+	for ch := range s.subscribers {
+		select {
+		case ch <- entry:
+		default:
+		}
+	}
+}
+
+// ordered returns a copy of the entries, from the oldest to the most recent one.
+// It must be called with the lock held.
+func (s *inMemoryStore) ordered() []InMemoryLogEntry {
+	return slices.Concat(s.entries[s.start:], s.entries[:s.start])
+}
+
+// find returns the first entry matching all the matchers, and a channel closed when new entries are added.
+func (s *inMemoryStore) find(matchers []EntryMatcher) (InMemoryLogEntry, bool, <-chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, entry := range s.ordered() {
+		if matchesAll(entry, matchers) {
+			return entry, true, nil
+		}
+	}
+
+	if s.added == nil {
+		s.added = make(chan struct{})
+	}
+	return InMemoryLogEntry{}, false, s.added
+}
+
+// subscribe registers a channel that receives the entries added from now on.
+func (s *inMemoryStore) subscribe(buffer int) (<-chan InMemoryLogEntry, func()) {
+	ch := make(chan InMemoryLogEntry, buffer)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.subscribers == nil {
+		s.subscribers = make(map[chan InMemoryLogEntry]struct{})
+	}
+	s.subscribers[ch] = struct{}{}
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			s.mu.Lock()
+			defer s.mu.Unlock()
+
+			delete(s.subscribers, ch)
+			close(ch)
+		})
+	}
+}
+
 // NewInMemory creates and returns a new instance of InMemoryLogger with the provided log level.
 // Usage:
 //
@@ -49,27 +228,55 @@ func (e *InMemoryLogEntry) GetField(key string) (Field, bool) {
 //	}
 func NewInMemory(level Level) *InMemoryLogger {
 	return &InMemoryLogger{
-		level:   level,
-		fields:  nil, // No global fields by default
-		entries: nil, // Start with no entries
+		level:    newLevelVar(level),
+		fields:   nil, // No global fields by default
+		store:    newInMemoryStore(0),
+		registry: NewLevelRegistry(),
 	}
 }
 
 // log processes log messages by appending log entries if the log level permits.
 func (l *InMemoryLogger) log(ctx context.Context, lvl Level, message string, fields ...Field) {
-	if lvl < l.level {
+	l.write(ctx, time.Now(), lvl, message, fields)
+}
+
+// writeEntry writes an entry of a logger that wraps this logger.
+func (l *InMemoryLogger) writeEntry(ctx context.Context, e entry) {
+	t := e.time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	l.write(ctx, t, e.level, e.msg, e.fields)
+
+	if !e.terminate {
+		return
+	}
+	switch e.level {
+	case LevelPanic:
+		panic(e.msg)
+	case LevelFatal:
+		if l.exitOnFatal {
+			os.Exit(1)
+		}
+	}
+}
+
+// write appends the log entry with the given time, if the log level permits.
+func (l *InMemoryLogger) write(ctx context.Context, t time.Time, lvl Level, message string, fields []Field) {
+	if lvl < l.level.Level() {
 		return // Skip logging if the log level is below the configured level
 	}
 
 	// Merge global logger fields with the specific fields for this log entry
-	logFields := append(append(l.fields, fields...), ExtractTraceIDFieldsFromContext(ctx)...)
+	logFields := slices.Concat(l.fields, fields, extractContextFields(ctx, l.extractors))
 
 	// Store the log entry
-	l.entries = append(l.entries, InMemoryLogEntry{
-		Timestamp: time.Now(),
+	l.store.add(InMemoryLogEntry{
+		Timestamp: t,
 		Message:   message,
 		Fields:    logFields,
 		Level:     lvl.String(),
+		Logger:    l.name,
 	})
 }
 
@@ -93,31 +300,138 @@ func (l *InMemoryLogger) Debug(ctx context.Context, msg string, fields ...Field)
 	l.log(ctx, LevelDebug, msg, fields...)
 }
 
+// Trace logs a trace message.
+func (l *InMemoryLogger) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelTrace, msg, fields...)
+}
+
+// Panic logs a panic message and then panics.
+func (l *InMemoryLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelPanic, msg, fields...)
+	panic(msg)
+}
+
+// Fatal logs a fatal message. Unlike the other loggers, it does not exit the process,
+// so that the code calling Fatal can be tested. Loggers created with NewRingBuffer do exit.
+func (l *InMemoryLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelFatal, msg, fields...)
+	if l.exitOnFatal {
+		os.Exit(1)
+	}
+}
+
+// Log logs a message at the given level.
+func (l *InMemoryLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.log(ctx, level, msg, fields...)
+}
+
 // SetLevel updates the logger's log level.
 func (l *InMemoryLogger) SetLevel(level Level) error {
-	l.level = level
+	l.level.Set(level)
 	return nil
 }
 
 // GetLevel retrieves the current log level of the logger.
 func (l *InMemoryLogger) GetLevel() Level {
-	return l.level
+	return l.level.Level()
 }
 
-// Entries returns all the log entries stored by the logger.
+// Entries returns a copy of all the log entries stored by the logger and its child loggers.
 func (l *InMemoryLogger) Entries() []InMemoryLogEntry {
-	return l.entries
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+
+	return l.store.ordered()
+}
+
+// Filter returns the entries matching all the matchers.
+// Usage:
+//
+//	entries := logger.Filter(log.MatchLevel(log.LevelError), log.MatchField("status", 500))
+func (l *InMemoryLogger) Filter(matchers ...EntryMatcher) []InMemoryLogEntry {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+
+	var entries []InMemoryLogEntry
+	for _, entry := range l.store.ordered() {
+		if matchesAll(entry, matchers) {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// WaitFor waits until an entry matching all the matchers is logged, or the timeout elapses.
+// It returns the first matching entry, including the entries logged before calling WaitFor,
+// and false if no entry matched within the timeout.
+func (l *InMemoryLogger) WaitFor(timeout time.Duration, matchers ...EntryMatcher) (InMemoryLogEntry, bool) {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	for {
+		entry, ok, added := l.store.find(matchers)
+		if ok {
+			return entry, true
+		}
+
+		select {
+		case <-added:
+		case <-timer.C:
+			return InMemoryLogEntry{}, false
+		}
+	}
+}
+
+// Reset removes all the entries stored by the logger and its child loggers.
+func (l *InMemoryLogger) Reset() {
+	l.store.mu.Lock()
+	defer l.store.mu.Unlock()
+
+	clear(l.store.entries)
+	l.store.entries = l.store.entries[:0]
+	l.store.start = 0
 }
 
 // With returns a new logger instance with the additional fields.
 func (l *InMemoryLogger) With(fields ...Field) Logger {
 	return &InMemoryLogger{
-		level:   l.level,
-		fields:  append(l.fields, fields...),
-		entries: l.entries,
+		level:       l.level,
+		name:        l.name,
+		fields:      slices.Concat(l.fields, fields),
+		store:       l.store,
+		registry:    l.registry,
+		exitOnFatal: l.exitOnFatal,
+		extractors:  l.extractors,
 	}
 }
 
+// Named returns a new logger instance with the given name appended to the logger name,
+// and a level of its own, starting at the current level of the parent logger.
+func (l *InMemoryLogger) Named(name string) Logger {
+	fullName := joinLoggerName(l.name, name)
+	return l.registry.register(fullName, func(registered Logger) Logger {
+		level := newLevelVar(l.level.Level())
+		if named, ok := registered.(*InMemoryLogger); ok {
+			level = named.level
+		}
+
+		return &InMemoryLogger{
+			level:       level,
+			name:        fullName,
+			fields:      l.fields,
+			store:       l.store,
+			registry:    l.registry,
+			exitOnFatal: l.exitOnFatal,
+			extractors:  l.extractors,
+		}
+	})
+}
+
+// Registry returns the registry of the named loggers created from this logger.
+func (l *InMemoryLogger) Registry() *LevelRegistry {
+	return l.registry
+}
+
 // Sync simulates synchronizing the logger (no-op for InMemoryLogger).
 func (l *InMemoryLogger) Sync() error {
 	return nil
@@ -2,9 +2,12 @@ package log_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/brpaz/lib-go/log"
 )
@@ -58,6 +61,25 @@ func TestInMemoryLoggerMethods(t *testing.T) {
 	}
 }
 
+func TestInMemoryLoggerAdditionalLevels(t *testing.T) {
+	logger := log.NewInMemory(log.LevelTrace)
+	ctx := context.Background()
+
+	logger.Trace(ctx, "trace message")
+	logger.Fatal(ctx, "fatal message")
+	logger.Log(ctx, log.LevelPanic, "logged panic message")
+	assert.PanicsWithValue(t, "panic message", func() {
+		logger.Panic(ctx, "panic message")
+	})
+
+	entries := logger.Entries()
+	require.Len(t, entries, 4)
+	assert.Equal(t, "trace", entries[0].Level)
+	assert.Equal(t, "fatal", entries[1].Level)
+	assert.Equal(t, "panic", entries[2].Level)
+	assert.Equal(t, "panic", entries[3].Level)
+}
+
 func TestInMemoryLoggerShouldLog(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -72,6 +94,8 @@ func TestInMemoryLoggerShouldLog(t *testing.T) {
 		{"WarnLogged", log.LevelInfo, log.LevelWarn, true},
 		{"WarnNotLogged", log.LevelError, log.LevelWarn, false},
 		{"ErrorLogged", log.LevelDebug, log.LevelError, true},
+		{"TraceLogged", log.LevelTrace, log.LevelTrace, true},
+		{"TraceNotLogged", log.LevelDebug, log.LevelTrace, false},
 	}
 
 	for _, tt := range tests {
@@ -89,6 +113,8 @@ func TestInMemoryLoggerShouldLog(t *testing.T) {
 				logger.Error(ctx, "error message")
 			case log.LevelDebug:
 				logger.Debug(ctx, "debug message")
+			case log.LevelTrace:
+				logger.Trace(ctx, "trace message")
 			}
 
 			// Verify if the log entry is present based on the level
@@ -117,6 +143,95 @@ func TestInMemoryWith(t *testing.T) {
 	assert.Len(t, childLogger.Entries(), 1)
 	assert.Equal(t, "key", childLogger.Entries()[0].Fields[0].Key)
 	assert.Equal(t, "value", childLogger.Entries()[0].Fields[0].String)
+
+	// The entries of the child logger are shared with the parent logger
+	assert.Equal(t, childLogger.Entries(), logger.Entries())
+}
+
+func TestInMemoryLoggerConcurrentLogging(t *testing.T) {
+	logger := log.NewInMemory(log.LevelDebug)
+
+	var wg sync.WaitGroup
+	for i := range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			child := logger.With(log.Int("worker", i)).Named("worker")
+			for range 100 {
+				child.Info(context.Background(), "message")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, logger.Entries(), 1000)
+	assert.Len(t, logger.Filter(log.MatchField("worker", 3)), 100)
+}
+
+func TestInMemoryLoggerFilter(t *testing.T) {
+	logger := log.NewInMemory(log.LevelDebug)
+	ctx := context.Background()
+
+	logger.Info(ctx, "request handled", log.Int("status", 200))
+	logger.Error(ctx, "request failed", log.Int("status", 500), log.String("path", "/users"))
+	logger.Named("db").Error(ctx, "query failed")
+
+	assert.Len(t, logger.Filter(), 3)
+	assert.Len(t, logger.Filter(log.MatchLevel(log.LevelError)), 2)
+	assert.Len(t, logger.Filter(log.MatchMessageContains("request")), 2)
+	assert.Len(t, logger.Filter(log.MatchLogger("db")), 1)
+
+	entries := logger.Filter(log.MatchLevel(log.LevelError), log.MatchField("status", 500))
+	require.Len(t, entries, 1)
+	assert.Equal(t, "request failed", entries[0].Message)
+
+	assert.Empty(t, logger.Filter(log.MatchMessage("request failed"), log.MatchField("status", 200)))
+	assert.Empty(t, logger.Filter(log.MatchField("path", "/orders")))
+	assert.Len(t, logger.Filter(log.MatchAll(log.MatchLevel(log.LevelError), log.MatchMessageContains("request"))), 1)
+}
+
+func TestInMemoryLoggerWaitFor(t *testing.T) {
+	t.Run("EntryLoggedLater", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelDebug)
+
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			logger.Info(context.Background(), "other message")
+			logger.With(log.String("job", "sync")).Info(context.Background(), "job done")
+		}()
+
+		entry, ok := logger.WaitFor(time.Second, log.MatchMessage("job done"))
+		require.True(t, ok)
+		assert.Equal(t, "job done", entry.Message)
+	})
+
+	t.Run("EntryAlreadyLogged", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelDebug)
+		logger.Info(context.Background(), "job done")
+
+		_, ok := logger.WaitFor(time.Second, log.MatchMessage("job done"))
+		assert.True(t, ok)
+	})
+
+	t.Run("Timeout", func(t *testing.T) {
+		logger := log.NewInMemory(log.LevelDebug)
+
+		_, ok := logger.WaitFor(10*time.Millisecond, log.MatchMessage("job done"))
+		assert.False(t, ok)
+	})
+}
+
+func TestInMemoryLoggerReset(t *testing.T) {
+	logger := log.NewInMemory(log.LevelDebug)
+	child := logger.With(log.String("key", "value"))
+
+	child.Info(context.Background(), "message")
+	logger.Reset()
+
+	assert.Empty(t, logger.Entries())
+
+	child.Info(context.Background(), "message")
+	assert.Len(t, logger.Entries(), 1)
 }
 
 func TestInMemoryLoggerSync(t *testing.T) {
@@ -130,8 +245,8 @@ func TestInMemoryLoggerSync(t *testing.T) {
 func TestInMemoryLogEntryGetField(t *testing.T) {
 	entry := &log.InMemoryLogEntry{
 		Fields: []log.Field{
-			{Key: "key1", String: "value1"},
-			{Key: "key2", Integer: 10},
+			log.String("key1", "value1"),
+			log.Int("key2", 10),
 		},
 	}
 
@@ -141,6 +256,12 @@ func TestInMemoryLogEntryGetField(t *testing.T) {
 		assert.Equal(t, "value1", field.String)
 	})
 
+	t.Run("ExistingFieldValue", func(t *testing.T) {
+		field, ok := entry.GetField("key2")
+		assert.True(t, ok)
+		assert.Equal(t, int64(10), field.Value())
+	})
+
 	t.Run("NonExistingField", func(t *testing.T) {
 		_, ok := entry.GetField("key3")
 		assert.False(t, ok)
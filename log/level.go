@@ -3,6 +3,7 @@ package log
 import (
 	"errors"
 	"strings"
+	"sync/atomic"
 )
 
 // Level defines all available log levels for log messages.
@@ -10,25 +11,31 @@ type Level int
 
 // Log levels supported by the application.
 const (
-	LevelDebug Level = iota
+	LevelTrace Level = iota - 1
+	LevelDebug
 	LevelInfo
 	LevelWarn
 	LevelError
+	LevelPanic
+	LevelFatal
 )
 
 // levelNames maps Level values to their string representations.
-var levelNames = []string{
-	"debug",
-	"info",
-	"warn",
-	"error",
+var levelNames = map[Level]string{
+	LevelTrace: "trace",
+	LevelDebug: "debug",
+	LevelInfo:  "info",
+	LevelWarn:  "warn",
+	LevelError: "error",
+	LevelPanic: "panic",
+	LevelFatal: "fatal",
 }
 
 // levelLookup provides a case-insensitive lookup for log levels by name.
 var levelLookup = func() map[string]Level {
 	lookup := make(map[string]Level, len(levelNames))
-	for i, name := range levelNames {
-		lookup[strings.ToLower(name)] = Level(i)
+	for lvl, name := range levelNames {
+		lookup[strings.ToLower(name)] = lvl
 	}
 	return lookup
 }()
@@ -39,10 +46,10 @@ var ErrInvalidLevel = errors.New("logger: invalid log level")
 // String returns the string representation of a logging level.
 // If the level is invalid, it returns "unknown".
 func (p Level) String() string {
-	if p < LevelDebug || p > LevelError {
-		return "unknown"
+	if name, ok := levelNames[p]; ok {
+		return name
 	}
-	return levelNames[p]
+	return "unknown"
 }
 
 // LevelFromString returns the log level from a string representation.
@@ -53,3 +60,25 @@ func LevelFromString(level string) (Level, error) {
 	}
 	return LevelInfo, ErrInvalidLevel
 }
+
+// levelVar is a Level that can be shared and updated concurrently by multiple loggers.
+type levelVar struct {
+	v atomic.Int64
+}
+
+// newLevelVar creates a new levelVar set to the given level.
+func newLevelVar(level Level) *levelVar {
+	v := &levelVar{}
+	v.Set(level)
+	return v
+}
+
+// Level returns the current level.
+func (v *levelVar) Level() Level {
+	return Level(v.v.Load())
+}
+
+// Set updates the level.
+func (v *levelVar) Set(level Level) {
+	v.v.Store(int64(level))
+}
@@ -16,6 +16,7 @@ func TestLevelFromString_ValidLevels(t *testing.T) {
 		input    string
 		expected log.Level
 	}{
+		{name: "Lowercase Trace", input: "trace", expected: log.LevelTrace},
 		{name: "Lowercase Debug", input: "debug", expected: log.LevelDebug},
 		{name: "Lowercase Info", input: "info", expected: log.LevelInfo},
 		{name: "Lowercase Warn", input: "warn", expected: log.LevelWarn},
@@ -24,6 +25,8 @@ func TestLevelFromString_ValidLevels(t *testing.T) {
 		{name: "Uppercase Info", input: "INFO", expected: log.LevelInfo},
 		{name: "Uppercase Warn", input: "WARN", expected: log.LevelWarn},
 		{name: "Uppercase Error", input: "ERROR", expected: log.LevelError},
+		{name: "Lowercase Panic", input: "panic", expected: log.LevelPanic},
+		{name: "Uppercase Fatal", input: "FATAL", expected: log.LevelFatal},
 	}
 
 	for _, test := range tests {
@@ -65,6 +68,10 @@ func TestLevel_String(t *testing.T) {
 		level    log.Level
 		expected string
 	}{
+		{
+			level:    log.LevelTrace,
+			expected: "trace",
+		},
 		{
 			level:    log.LevelDebug,
 			expected: "debug",
@@ -82,11 +89,15 @@ func TestLevel_String(t *testing.T) {
 			expected: "error",
 		},
 		{
-			level:    log.Level(100),
-			expected: "unknown",
+			level:    log.LevelPanic,
+			expected: "panic",
+		},
+		{
+			level:    log.LevelFatal,
+			expected: "fatal",
 		},
 		{
-			level:    log.Level(-1),
+			level:    log.Level(100),
 			expected: "unknown",
 		},
 	}
@@ -99,3 +110,11 @@ func TestLevel_String(t *testing.T) {
 		})
 	}
 }
+
+func TestLevel_Order(t *testing.T) {
+	t.Parallel()
+
+	assert.Less(t, log.LevelTrace, log.LevelDebug)
+	assert.Less(t, log.LevelError, log.LevelPanic)
+	assert.Less(t, log.LevelPanic, log.LevelFatal)
+}
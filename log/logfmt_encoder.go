@@ -0,0 +1,259 @@
+package log
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+// logfmtTimeLayout is the layout used to encode times, matching zapcore.ISO8601TimeEncoder.
+const logfmtTimeLayout = "2006-01-02T15:04:05.000Z0700"
+
+var logfmtPool = buffer.NewPool()
+
+// logfmtEncoder is a zapcore.Encoder that writes entries in the logfmt format (key=value pairs).
+// Nested objects are flattened using dotted keys, while arrays and reflected values are encoded as JSON.
+type logfmtEncoder struct {
+	cfg       zapcore.EncoderConfig
+	buf       *buffer.Buffer
+	namespace string
+}
+
+// NewLogfmtEncoder creates a zapcore.Encoder that writes entries in the logfmt format.
+// The entry keys (time, level, message, logger, caller and stacktrace) are taken from the
+// encoder config and omitted when empty. Times are encoded in the ISO8601 format, levels in
+// lowercase and durations using their string representation.
+func NewLogfmtEncoder(cfg zapcore.EncoderConfig) zapcore.Encoder {
+	return &logfmtEncoder{
+		cfg: cfg,
+		buf: logfmtPool.Get(),
+	}
+}
+
+// Clone copies the encoder, including the fields added with the With method of the logger.
+func (e *logfmtEncoder) Clone() zapcore.Encoder {
+	clone := &logfmtEncoder{
+		cfg:       e.cfg,
+		buf:       logfmtPool.Get(),
+		namespace: e.namespace,
+	}
+	_, _ = clone.buf.Write(e.buf.Bytes())
+	return clone
+}
+
+// EncodeEntry encodes the entry and the fields into a single logfmt line.
+func (e *logfmtEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line := &logfmtEncoder{
+		cfg: e.cfg,
+		buf: logfmtPool.Get(),
+	}
+
+	if e.cfg.TimeKey != "" && !ent.Time.IsZero() {
+		line.AddString(e.cfg.TimeKey, ent.Time.Format(logfmtTimeLayout))
+	}
+	if e.cfg.LevelKey != "" {
+		line.AddString(e.cfg.LevelKey, zapLevelName(ent.Level))
+	}
+	if e.cfg.NameKey != "" && ent.LoggerName != "" {
+		line.AddString(e.cfg.NameKey, ent.LoggerName)
+	}
+	if e.cfg.CallerKey != "" && ent.Caller.Defined {
+		line.AddString(e.cfg.CallerKey, ent.Caller.TrimmedPath())
+	}
+	if e.cfg.MessageKey != "" {
+		line.AddString(e.cfg.MessageKey, ent.Message)
+	}
+
+	if e.buf.Len() > 0 {
+		line.separate()
+		_, _ = line.buf.Write(e.buf.Bytes())
+	}
+
+	line.namespace = e.namespace
+	for _, f := range fields {
+		f.AddTo(line)
+	}
+	line.namespace = ""
+
+	if e.cfg.StacktraceKey != "" && ent.Stack != "" {
+		line.AddString(e.cfg.StacktraceKey, ent.Stack)
+	}
+
+	line.buf.AppendByte('\n')
+	return line.buf, nil
+}
+
+// AddArray adds an array, encoded as JSON.
+func (e *logfmtEncoder) AddArray(key string, arr zapcore.ArrayMarshaler) error {
+	enc := zapcore.NewMapObjectEncoder()
+	if err := enc.AddArray(key, arr); err != nil {
+		return err
+	}
+	return e.AddReflected(key, enc.Fields[key])
+}
+
+// AddObject adds an object, flattening its fields using the key as prefix.
+func (e *logfmtEncoder) AddObject(key string, obj zapcore.ObjectMarshaler) error {
+	namespace := e.namespace
+	e.namespace = namespace + key + "."
+	err := obj.MarshalLogObject(e)
+	e.namespace = namespace
+	return err
+}
+
+// AddBinary adds a byte slice, encoded as base64.
+func (e *logfmtEncoder) AddBinary(key string, value []byte) {
+	e.AddString(key, base64.StdEncoding.EncodeToString(value))
+}
+
+// AddByteString adds a UTF-8 encoded byte slice.
+func (e *logfmtEncoder) AddByteString(key string, value []byte) {
+	e.AddString(key, string(value))
+}
+
+// AddBool adds a bool.
+func (e *logfmtEncoder) AddBool(key string, value bool) {
+	e.addKey(key)
+	e.buf.AppendBool(value)
+}
+
+// AddComplex128 adds a complex128.
+func (e *logfmtEncoder) AddComplex128(key string, value complex128) {
+	e.AddString(key, strconv.FormatComplex(value, 'g', -1, 128))
+}
+
+// AddComplex64 adds a complex64.
+func (e *logfmtEncoder) AddComplex64(key string, value complex64) {
+	e.AddString(key, strconv.FormatComplex(complex128(value), 'g', -1, 64))
+}
+
+// AddDuration adds a time.Duration, using its string representation.
+func (e *logfmtEncoder) AddDuration(key string, value time.Duration) {
+	e.AddString(key, value.String())
+}
+
+// AddFloat64 adds a float64.
+func (e *logfmtEncoder) AddFloat64(key string, value float64) {
+	e.addKey(key)
+	e.buf.AppendFloat(value, 64)
+}
+
+// AddFloat32 adds a float32.
+func (e *logfmtEncoder) AddFloat32(key string, value float32) {
+	e.addKey(key)
+	e.buf.AppendFloat(float64(value), 32)
+}
+
+// AddInt adds an int.
+func (e *logfmtEncoder) AddInt(key string, value int) { e.AddInt64(key, int64(value)) }
+
+// AddInt64 adds an int64.
+func (e *logfmtEncoder) AddInt64(key string, value int64) {
+	e.addKey(key)
+	e.buf.AppendInt(value)
+}
+
+// AddInt32 adds an int32.
+func (e *logfmtEncoder) AddInt32(key string, value int32) { e.AddInt64(key, int64(value)) }
+
+// AddInt16 adds an int16.
+func (e *logfmtEncoder) AddInt16(key string, value int16) { e.AddInt64(key, int64(value)) }
+
+// AddInt8 adds an int8.
+func (e *logfmtEncoder) AddInt8(key string, value int8) { e.AddInt64(key, int64(value)) }
+
+// AddString adds a string, quoting it when required.
+func (e *logfmtEncoder) AddString(key, value string) {
+	e.addKey(key)
+	e.appendString(value)
+}
+
+// AddTime adds a time.Time, encoded in the ISO8601 format.
+func (e *logfmtEncoder) AddTime(key string, value time.Time) {
+	e.AddString(key, value.Format(logfmtTimeLayout))
+}
+
+// AddUint adds a uint.
+func (e *logfmtEncoder) AddUint(key string, value uint) { e.AddUint64(key, uint64(value)) }
+
+// AddUint64 adds a uint64.
+func (e *logfmtEncoder) AddUint64(key string, value uint64) {
+	e.addKey(key)
+	e.buf.AppendUint(value)
+}
+
+// AddUint32 adds a uint32.
+func (e *logfmtEncoder) AddUint32(key string, value uint32) { e.AddUint64(key, uint64(value)) }
+
+// AddUint16 adds a uint16.
+func (e *logfmtEncoder) AddUint16(key string, value uint16) { e.AddUint64(key, uint64(value)) }
+
+// AddUint8 adds a uint8.
+func (e *logfmtEncoder) AddUint8(key string, value uint8) { e.AddUint64(key, uint64(value)) }
+
+// AddUintptr adds a uintptr.
+func (e *logfmtEncoder) AddUintptr(key string, value uintptr) { e.AddUint64(key, uint64(value)) }
+
+// AddReflected adds an arbitrary value, encoded as JSON.
+func (e *logfmtEncoder) AddReflected(key string, value interface{}) error {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	e.AddString(key, string(b))
+	return nil
+}
+
+// OpenNamespace prefixes the keys of all the fields added afterwards with the given key.
+func (e *logfmtEncoder) OpenNamespace(key string) {
+	e.namespace += key + "."
+}
+
+// separate writes the space between key/value pairs.
+func (e *logfmtEncoder) separate() {
+	if e.buf.Len() > 0 {
+		e.buf.AppendByte(' ')
+	}
+}
+
+// addKey writes the key, replacing any character that is not allowed in logfmt keys.
+func (e *logfmtEncoder) addKey(key string) {
+	e.separate()
+	e.buf.AppendString(strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			return '_'
+		}
+		return r
+	}, e.namespace+key))
+	e.buf.AppendByte('=')
+}
+
+// appendString writes the value, quoting and escaping it when it contains spaces,
+// equal signs, quotes or non printable characters.
+func (e *logfmtEncoder) appendString(value string) {
+	if needsQuoting(value) {
+		e.buf.AppendString(strconv.Quote(value))
+		return
+	}
+	e.buf.AppendString(value)
+}
+
+// needsQuoting reports whether a logfmt value must be quoted.
+func needsQuoting(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !unicode.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,125 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// createLogfmtTestLogger creates a ZapAdapter that writes logfmt lines without timestamps to the buffer.
+func createLogfmtTestLogger(buf *bytes.Buffer) *log.ZapAdapter {
+	encoder := log.NewLogfmtEncoder(zapcore.EncoderConfig{
+		LevelKey:   "level",
+		MessageKey: "msg",
+	})
+	core := zapcore.NewCore(encoder, zapcore.AddSync(buf), zap.DebugLevel)
+	return &log.ZapAdapter{
+		ZapL:        zap.New(core),
+		AtomicLevel: zap.NewAtomicLevelAt(zapcore.DebugLevel),
+	}
+}
+
+func TestLogfmtEncoder(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		fields   []log.Field
+		expected string
+	}{
+		{name: "NoFields", expected: `level=info msg="hello world"`},
+		{name: "String", fields: []log.Field{log.String("key", "value")}, expected: `level=info msg="hello world" key=value`},
+		{name: "QuotedString", fields: []log.Field{log.String("key", `a "b" c`)}, expected: `level=info msg="hello world" key="a \"b\" c"`},
+		{name: "EmptyString", fields: []log.Field{log.String("key", "")}, expected: `level=info msg="hello world" key=""`},
+		{name: "EqualSign", fields: []log.Field{log.String("key", "a=b")}, expected: `level=info msg="hello world" key="a=b"`},
+		{name: "NewLine", fields: []log.Field{log.String("key", "a\nb")}, expected: `level=info msg="hello world" key="a\nb"`},
+		{name: "Unicode", fields: []log.Field{log.String("key", "olá")}, expected: `level=info msg="hello world" key=olá`},
+		{name: "InvalidKey", fields: []log.Field{log.String("my key", "value")}, expected: `level=info msg="hello world" my_key=value`},
+		{name: "Numbers", fields: []log.Field{log.Int("int", -1), log.Uint("uint", 1), log.Float64("float", 1.5)}, expected: `level=info msg="hello world" int=-1 uint=1 float=1.5`},
+		{name: "Bool", fields: []log.Field{log.Bool("ok", true)}, expected: `level=info msg="hello world" ok=true`},
+		{name: "Duration", fields: []log.Field{log.Duration("elapsed", 1500*time.Millisecond)}, expected: `level=info msg="hello world" elapsed=1.5s`},
+		{name: "Time", fields: []log.Field{log.Time("at", time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))}, expected: `level=info msg="hello world" at=2024-01-02T03:04:05.000Z`},
+		{name: "Error", fields: []log.Field{log.Err(errors.New("boom"))}, expected: `level=info msg="hello world" error=boom`},
+		{name: "Group", fields: []log.Field{log.Group("req", log.String("method", "GET"), log.Int("status", 200))}, expected: `level=info msg="hello world" req.method=GET req.status=200`},
+		{name: "Reflected", fields: []log.Field{log.Any("tags", []string{"a", "b"})}, expected: `level=info msg="hello world" tags="[\"a\",\"b\"]"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+			logger := createLogfmtTestLogger(buf)
+
+			logger.Info(context.Background(), "hello world", tt.fields...)
+
+			assert.Equal(t, tt.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestLogfmtEncoder_With(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := createLogfmtTestLogger(buf).With(log.String("service", "api"))
+
+	logger.Warn(context.Background(), "first")
+	logger.Warn(context.Background(), "second", log.Int("attempt", 2))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "level=warn msg=first service=api", lines[0])
+	assert.Equal(t, "level=warn msg=second service=api attempt=2", lines[1])
+}
+
+func TestZapAdapter_Formats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format   string
+		expected []string
+	}{
+		{format: log.FormatJSON, expected: []string{`"level":"info"`, `"msg":"hello world"`, `"key":"value"`}},
+		{format: log.FormatLogFmt, expected: []string{"level=info", `msg="hello world"`, "key=value"}},
+		{format: log.FormatConsole, expected: []string{"INFO", "hello world", `"key": "value"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "app.log")
+
+			logger, err := log.NewZap(log.ZapLoggerOpts{
+				Level:   log.LevelInfo,
+				Profile: log.ProfileDevelopment,
+				Format:  tt.format,
+				Outputs: []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+			})
+			require.NoError(t, err)
+
+			logger.Info(context.Background(), "hello world", log.String("key", "value"))
+			require.NoError(t, logger.Sync())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+
+			for _, expected := range tt.expected {
+				assert.Contains(t, string(content), expected)
+			}
+			// Colors are never used when writing to files
+			assert.NotContains(t, string(content), "\x1b[")
+		})
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"slices"
 )
 
@@ -12,48 +13,79 @@ const (
 	ProfileDevelopment = "dev"
 	ProfileProduction  = "prod"
 	AdapterZap         = "zap"
+	AdapterSlog        = "slog"
 	AdapterNop         = "nop"
 	AdapterInMemory    = "in-memory"
+	AdapterOtel        = "otel"
 	FormatJSON         = "json"
 	FormatLogFmt       = "logfmt"
+	FormatConsole      = "console"
 )
 
 // LoggerOpts specifies the options that can be used to configure the logger instance.
 type LoggerOpts struct {
-	Adapter string
-	Level   Level
-	Profile string
-	Format  string
+	Adapter   string
+	Level     Level
+	Profile   string
+	Format    string
+	Outputs   []Output
+	Sampling  *SamplingOpts
+	Redaction *RedactionOpts
+	// ContextExtractors extract the fields added to every entry from the context.
+	// Defaults to DefaultContextExtractors when nil, while an empty slice disables the extraction.
+	ContextExtractors []ContextExtractor
+	// StacktraceLevel is the minimum level of the entries that include a stack trace.
+	// When nil, it defaults to Warn in the development profile and Error in the production profile.
+	StacktraceLevel *Level
+	// DisableStacktrace disables the stack traces, for every level.
+	DisableStacktrace bool
+	// SpanEvents records the entries at the Warn level and above as events of the active span.
+	SpanEvents bool
+	// Async writes the entries from a background goroutine, through a bounded buffer.
+	Async *AsyncOpts
 }
 
 // LoggerOpt is a functional option type to configure the logger.
 type LoggerOpt func(*LoggerOpts)
 
 // Logger is a generic interface that defines the methods required by a logger.
+//
+// Panic logs the message and then panics, while Fatal logs the message, flushes the logger
+// and then calls os.Exit(1). Log writes a message at the given level without panicking or
+// exiting, even for the Panic and Fatal levels.
+//
+// With returns a child logger that shares the level of its parent, while Named returns a
+// child logger with its own level, which can be changed independently by name through the
+// LevelRegistry of the logger (see RegistryOf).
 type Logger interface {
+	Trace(ctx context.Context, msg string, fields ...Field)
+	Debug(ctx context.Context, msg string, fields ...Field)
 	Info(ctx context.Context, msg string, fields ...Field)
 	Warn(ctx context.Context, msg string, fields ...Field)
 	Error(ctx context.Context, msg string, fields ...Field)
-	Debug(ctx context.Context, msg string, fields ...Field)
+	Panic(ctx context.Context, msg string, fields ...Field)
+	Fatal(ctx context.Context, msg string, fields ...Field)
+	Log(ctx context.Context, level Level, msg string, fields ...Field)
 	SetLevel(level Level) error
 	GetLevel() Level
 	With(fields ...Field) Logger
+	Named(name string) Logger
 	Sync() error
 }
 
 // Default values for LoggerOpts
+// The format is set from the profile, when not set explicitly (see profileFormat).
 var defaultOpts = LoggerOpts{
 	Level:   LevelInfo,
 	Profile: ProfileProduction,
-	Format:  FormatJSON,
 	Adapter: AdapterZap,
 }
 
 // Available valid options for profile, format, and adapter.
 var (
 	allowedProfiles = []string{ProfileDevelopment, ProfileProduction}
-	allowedFormats  = []string{FormatJSON, FormatLogFmt}
-	allowedAdapters = []string{AdapterZap, AdapterNop, AdapterInMemory}
+	allowedFormats  = []string{FormatJSON, FormatLogFmt, FormatConsole}
+	allowedAdapters = []string{AdapterZap, AdapterSlog, AdapterNop, AdapterInMemory, AdapterOtel}
 )
 
 var (
@@ -76,8 +108,9 @@ func WithProfile(profile string) LoggerOpt {
 	}
 }
 
-// WithFormat specifies the log format to be used by the logger. Supported formats are json and console.
-// This option will be only applicable for the Zap adapter.
+// WithFormat specifies the log format to be used by the logger. Supported formats are json, logfmt and console.
+// This option will be only applicable for the Zap and Slog adapters. Defaults to console in the development
+// profile and json in the production profile.
 func WithFormat(format string) LoggerOpt {
 	return func(o *LoggerOpts) {
 		o.Format = format
@@ -102,6 +135,19 @@ func (opts *LoggerOpts) Validate() error {
 	if !slices.Contains(allowedAdapters, opts.Adapter) {
 		return fmt.Errorf("%w: %s. Allowed values are %v", ErrInvalidAdapter, opts.Adapter, allowedAdapters)
 	}
+	if err := validateOutputs(opts.Outputs); err != nil {
+		return err
+	}
+	if opts.Sampling != nil {
+		if err := opts.Sampling.Validate(); err != nil {
+			return err
+		}
+	}
+	if opts.Async != nil {
+		if err := opts.Async.Validate(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -133,23 +179,98 @@ func New(options ...LoggerOpt) (Logger, error) {
 		option(&opts)
 	}
 
+	if opts.Format == "" {
+		opts.Format = profileFormat(opts.Profile)
+	}
+
 	// Validate options
 	if err := opts.Validate(); err != nil {
 		return nil, fmt.Errorf("failed to create logger: %w", err)
 	}
 
-	// Create logger based on selected adapter
+	logger, err := newAdapter(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// Span events are recorded synchronously, while the span is still active.
+	if opts.Async != nil {
+		logger = NewAsync(logger, *opts.Async)
+	}
+
+	if opts.SpanEvents {
+		logger = NewSpanEventLogger(logger)
+	}
+
+	if opts.Redaction != nil {
+		logger = NewRedactingLogger(logger, NewRedactor(*opts.Redaction))
+	}
+
+	if opts.Sampling != nil {
+		logger = NewSampler(logger, *opts.Sampling)
+	}
+
+	return logger, nil
+}
+
+// profileFormat returns the default format of the profile: console in the development profile,
+// for human-readable output, and json otherwise.
+func profileFormat(profile string) string {
+	if profile == ProfileDevelopment {
+		return FormatConsole
+	}
+	return FormatJSON
+}
+
+// Close flushes the logger and releases its resources, like the background goroutine of the
+// asynchronous logger and the files of the file outputs. The loggers that do not hold resources
+// are only synced. It should be called before the application exits, instead of Sync:
+//
+//	logger, err := log.New(log.WithRotatingFile(log.FileOutputOpts{Path: "/var/log/app.log"}))
+//	defer log.Close(logger)
+func Close(logger Logger) error {
+	if closer, ok := logger.(io.Closer); ok {
+		return closer.Close()
+	}
+	return logger.Sync()
+}
+
+// newAdapter creates the logger for the selected adapter.
+func newAdapter(opts LoggerOpts) (Logger, error) {
 	switch opts.Adapter {
 	case AdapterZap:
 		return NewZap(ZapLoggerOpts{
-			Level:   opts.Level,
-			Profile: opts.Profile,
-			Format:  opts.Format,
+			Level:             opts.Level,
+			Profile:           opts.Profile,
+			Format:            opts.Format,
+			Outputs:           opts.Outputs,
+			ContextExtractors: opts.ContextExtractors,
+			StacktraceLevel:   opts.StacktraceLevel,
+			DisableStacktrace: opts.DisableStacktrace,
+			// The entries are sampled once, by the SampledLogger
+			DisableSampling: opts.Sampling != nil,
+		})
+	case AdapterSlog:
+		return NewSlog(SlogLoggerOpts{
+			Level:             opts.Level,
+			Profile:           opts.Profile,
+			Format:            opts.Format,
+			Outputs:           opts.Outputs,
+			ContextExtractors: opts.ContextExtractors,
+			StacktraceLevel:   opts.StacktraceLevel,
+			DisableStacktrace: opts.DisableStacktrace,
 		})
+	case AdapterOtel:
+		return NewOtel(OtelLoggerOpts{
+			Level:             opts.Level,
+			ContextExtractors: opts.ContextExtractors,
+		}), nil
 	case AdapterNop:
 		return NewNop(), nil
 	case AdapterInMemory:
-		return NewInMemory(opts.Level), nil
+		logger := NewInMemory(opts.Level)
+		logger.extractors = opts.ContextExtractors
+		return logger, nil
 	default:
 		return nil, ErrInvalidAdapter
 	}
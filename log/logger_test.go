@@ -1,6 +1,9 @@
 package log_test
 
 import (
+	"context"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -113,6 +116,35 @@ func TestNewLogger(t *testing.T) {
 		assert.Equal(t, log.LevelWarn, logger.GetLevel())
 	})
 
+	t.Run("SlogLogger", func(t *testing.T) {
+		t.Parallel()
+		logger, err := log.New(
+			log.WithAdapter(log.AdapterSlog),
+			log.WithLevel(log.LevelWarn),
+		)
+		require.NoError(t, err)
+		assert.IsType(t, &log.SlogAdapter{}, logger)
+		assert.Equal(t, log.LevelWarn, logger.GetLevel())
+	})
+
+	t.Run("DevelopmentProfileFormat", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "app.log")
+		logger, err := log.New(
+			log.WithProfile(log.ProfileDevelopment),
+			log.WithRotatingFile(log.FileOutputOpts{Path: path}),
+		)
+		require.NoError(t, err)
+
+		logger.Info(context.Background(), "message", log.String("key", "value"))
+		require.NoError(t, logger.Sync())
+
+		// The development profile defaults to the console format
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Regexp(t, `^\S+  INFO  log/logger_test\.go:\d+  message  \{"key": "value"\}\n$`, string(content))
+	})
+
 	t.Run("InvalidAdapter", func(t *testing.T) {
 		t.Parallel()
 		_, err := log.New(
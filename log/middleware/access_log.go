@@ -0,0 +1,268 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/brpaz/lib-go/httputil"
+	"github.com/brpaz/lib-go/log"
+)
+
+// Formats of the entries logged by the RequestLogger.
+const (
+	// AccessLogFormatDefault logs the request details as fields, with the DefaultRequestLogMessage.
+	AccessLogFormatDefault = "default"
+	// AccessLogFormatCommon logs the request as an Apache Common Log Format line, in the message.
+	AccessLogFormatCommon = "common"
+	// AccessLogFormatCombined logs the request as an Apache Combined Log Format line, in the message.
+	AccessLogFormatCombined = "combined"
+	// AccessLogFormatECS logs the request details as fields named after the Elastic Common Schema,
+	// like http.request.method and url.path.
+	AccessLogFormatECS = "ecs"
+	// AccessLogFormatGCP logs the request details in an httpRequest field, following the structure of
+	// the HttpRequest of the Google Cloud Logging entries.
+	AccessLogFormatGCP = "gcp"
+)
+
+// accessLogFormats are the formats accepted by the RequestLogger.
+var accessLogFormats = []string{
+	AccessLogFormatDefault,
+	AccessLogFormatCommon,
+	AccessLogFormatCombined,
+	AccessLogFormatECS,
+	AccessLogFormatGCP,
+}
+
+// clfTimeLayout is the layout of the time of the Common Log Format lines.
+const clfTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// accessLogRecord holds the details of a request handled by the RequestLogger.
+type accessLogRecord struct {
+	r            *http.Request
+	lrw          *httputil.ResponseWriter
+	start        time.Time
+	duration     time.Duration
+	requestBody  *bodyCapture
+	responseBody *bodyCapture
+}
+
+// parseAccessLogFormat returns the lowercase format, or AccessLogFormatDefault if it is empty,
+// and an error if it is unknown.
+func parseAccessLogFormat(format string) (string, error) {
+	if format == "" {
+		return AccessLogFormatDefault, nil
+	}
+
+	format = strings.ToLower(format)
+	if !slices.Contains(accessLogFormats, format) {
+		return "", fmt.Errorf("middleware: unknown access log format %q", format)
+	}
+	return format, nil
+}
+
+// buildAccessLogEntry returns the message and the fields of the entry of the request, in the format of the config.
+func buildAccessLogEntry(rec accessLogRecord, config *RequestLoggerConfig) (string, []log.Field) {
+	switch config.Format {
+	case AccessLogFormatCommon, AccessLogFormatCombined:
+		return buildCLFLine(rec, config), nil
+	case AccessLogFormatECS:
+		return DefaultRequestLogMessage, buildECSFields(rec, config)
+	case AccessLogFormatGCP:
+		return DefaultRequestLogMessage, buildGCPFields(rec, config)
+	default:
+		return DefaultRequestLogMessage, buildLogFieldsFromConfig(rec.r, rec.lrw, config, rec.duration, rec.requestBody, rec.responseBody)
+	}
+}
+
+// buildCLFLine formats the request as a Common Log Format line, or a Combined Log Format line,
+// with the referer and the user agent. The quoted values are escaped, to prevent log injection,
+// and the query is only included in the request line if LogRequestQueryParams is set.
+func buildCLFLine(rec accessLogRecord, config *RequestLoggerConfig) string {
+	user := "-"
+	if username, _, ok := rec.r.BasicAuth(); ok && username != "" {
+		user = username
+	}
+	size := "-"
+	if n := rec.lrw.BytesWritten(); n > 0 {
+		size = strconv.FormatInt(n, 10)
+	}
+
+	var sb strings.Builder
+	sb.WriteString(clientIP(rec.r))
+	sb.WriteString(" - ")
+	sb.WriteString(escapeCLF(user))
+	sb.WriteString(" [")
+	sb.WriteString(rec.start.Format(clfTimeLayout))
+	sb.WriteString("] ")
+	sb.WriteString(strconv.Quote(rec.r.Method + " " + requestURI(rec.r, config.LogRequestQueryParams) + " " + rec.r.Proto))
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.Itoa(responseStatus(rec.lrw)))
+	sb.WriteByte(' ')
+	sb.WriteString(size)
+
+	if config.Format == AccessLogFormatCombined {
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.Quote(valueOrDash(rec.r.Referer())))
+		sb.WriteByte(' ')
+		sb.WriteString(strconv.Quote(valueOrDash(rec.r.UserAgent())))
+	}
+
+	// Mask sensitive data, like credentials in the query parameters
+	return redactorOrDefault(config.Redactor).RedactString(sb.String())
+}
+
+// buildECSFields returns the fields of the request, named after the Elastic Common Schema.
+func buildECSFields(rec accessLogRecord, config *RequestLoggerConfig) []log.Field {
+	r := rec.r
+	fields := []log.Field{
+		log.String("http.version", strings.TrimPrefix(r.Proto, "HTTP/")),
+	}
+
+	if config.LogMethod {
+		fields = append(fields, log.String("http.request.method", r.Method))
+	}
+	if config.LogPath {
+		fields = append(fields, log.String("url.path", r.URL.Path))
+	}
+	if config.LogRequestQueryParams && r.URL.RawQuery != "" {
+		fields = append(fields, log.String("url.query", r.URL.RawQuery))
+	}
+	if config.LogStatus {
+		fields = append(fields, log.Int("http.response.status_code", responseStatus(rec.lrw)))
+	}
+	if config.LogDuration {
+		fields = append(fields, log.Int64("event.duration", rec.duration.Nanoseconds()))
+	}
+	// The size is unknown when it is -1, like for chunked requests
+	if config.LogRequestSize && r.ContentLength >= 0 {
+		fields = append(fields, log.Int64("http.request.body.bytes", r.ContentLength))
+	}
+	if config.LogResponseSize {
+		fields = append(fields, log.Int64("http.response.body.bytes", rec.lrw.BytesWritten()))
+	}
+	if config.LogRemoteAddr {
+		fields = append(fields, log.String("client.address", clientIP(r)))
+	}
+	if config.LogUserAgent {
+		fields = append(fields, log.String("user_agent.original", r.UserAgent()))
+	}
+	if referer := r.Referer(); referer != "" {
+		fields = append(fields, log.String("http.request.referrer", referer))
+	}
+	if config.LogRequestHeaders {
+		fields = append(fields, headerFields("http.request.headers.", r.Header)...)
+	}
+	if config.LogResponseHeaders {
+		fields = append(fields, headerFields("http.response.headers.", rec.lrw.Header())...)
+	}
+	fields = append(fields, bodyFields("http.request.body.content", rec.requestBody)...)
+	fields = append(fields, bodyFields("http.response.body.content", rec.responseBody)...)
+
+	return redactorOrDefault(config.Redactor).Redact(fields...)
+}
+
+// buildGCPFields returns the httpRequest field of the request, following the structure of the HttpRequest
+// of the Google Cloud Logging entries, and the header and body fields, which have no equivalent in it.
+func buildGCPFields(rec accessLogRecord, config *RequestLoggerConfig) []log.Field {
+	r := rec.r
+	httpRequest := []log.Field{
+		log.String("protocol", r.Proto),
+	}
+
+	if config.LogMethod {
+		httpRequest = append(httpRequest, log.String("requestMethod", r.Method))
+	}
+	if config.LogPath {
+		httpRequest = append(httpRequest, log.String("requestUrl", requestURL(r, config.LogRequestQueryParams)))
+	}
+	if config.LogStatus {
+		httpRequest = append(httpRequest, log.Int("status", responseStatus(rec.lrw)))
+	}
+	if config.LogDuration {
+		// The latency is a google.protobuf.Duration, encoded in JSON as the seconds followed by "s"
+		httpRequest = append(httpRequest, log.String("latency", strconv.FormatFloat(rec.duration.Seconds(), 'f', -1, 64)+"s"))
+	}
+	// The sizes are int64 values, encoded in JSON as strings
+	if config.LogRequestSize && r.ContentLength >= 0 {
+		httpRequest = append(httpRequest, log.String("requestSize", strconv.FormatInt(r.ContentLength, 10)))
+	}
+	if config.LogResponseSize {
+		httpRequest = append(httpRequest, log.String("responseSize", strconv.FormatInt(rec.lrw.BytesWritten(), 10)))
+	}
+	if config.LogRemoteAddr {
+		httpRequest = append(httpRequest, log.String("remoteIp", clientIP(r)))
+	}
+	if config.LogUserAgent {
+		httpRequest = append(httpRequest, log.String("userAgent", r.UserAgent()))
+	}
+	if referer := r.Referer(); referer != "" {
+		httpRequest = append(httpRequest, log.String("referer", referer))
+	}
+
+	fields := []log.Field{log.Group("httpRequest", httpRequest...)}
+	if config.LogRequestHeaders {
+		fields = append(fields, headerFields("header_", r.Header)...)
+	}
+	if config.LogResponseHeaders {
+		fields = append(fields, headerFields("response_header_", rec.lrw.Header())...)
+	}
+	fields = append(fields, bodyFields("request_body", rec.requestBody)...)
+	fields = append(fields, bodyFields("response_body", rec.responseBody)...)
+
+	return redactorOrDefault(config.Redactor).Redact(fields...)
+}
+
+// clientIP returns the IP address of the client, without the port.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// requestURI returns the unmodified request target of the request line, or the one built from the URL,
+// with the query only if includeQuery is true.
+func requestURI(r *http.Request, includeQuery bool) string {
+	uri := r.RequestURI
+	if uri == "" {
+		uri = r.URL.RequestURI()
+	}
+	if !includeQuery {
+		uri, _, _ = strings.Cut(uri, "?")
+	}
+	return uri
+}
+
+// requestURL returns the absolute URL of the request, with the query only if includeQuery is true.
+func requestURL(r *http.Request, includeQuery bool) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	u := scheme + "://" + r.Host + r.URL.EscapedPath()
+	if includeQuery && r.URL.RawQuery != "" {
+		u += "?" + r.URL.RawQuery
+	}
+	return u
+}
+
+// escapeCLF escapes the quotes, backslashes and control characters of the value, like strconv.Quote, without quoting it.
+func escapeCLF(value string) string {
+	quoted := strconv.Quote(value)
+	return quoted[1 : len(quoted)-1]
+}
+
+// valueOrDash returns the value, or "-" if it is empty, like the empty values of the Common Log Format.
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
@@ -0,0 +1,175 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+	"github.com/brpaz/lib-go/log/middleware"
+)
+
+// serveWithFormat serves a request through the RequestLogger with the format and returns the logged entry.
+func serveWithFormat(t *testing.T, format string, req *http.Request) log.InMemoryLogEntry {
+	t.Helper()
+
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("Hello, World!"))
+	})
+
+	config := middleware.DefaultRequestLoggerConfig()
+	config.LogRequestQueryParams = true
+	config.Format = format
+	middleware.RequestLogger(logger, &config)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	return entries[0]
+}
+
+func newAccessLogRequest() *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/orders?page=1&access_token=abc123", nil)
+	req.SetBasicAuth("john", "secret")
+	req.Header.Set("User-Agent", `curl/8.0 "quoted"`)
+	req.Header.Set("Referer", "https://example.com/")
+	return req
+}
+
+func TestRequestLogger_CommonLogFormat(t *testing.T) {
+	t.Parallel()
+
+	entry := serveWithFormat(t, middleware.AccessLogFormatCommon, newAccessLogRequest())
+
+	assert.Regexp(t,
+		`^192\.0\.2\.1 - john \[\d{2}/\w{3}/\d{4}:\d{2}:\d{2}:\d{2} [+-]\d{4}\] "GET /orders\?page=1&access_token=\[REDACTED\] HTTP/1\.1" 201 13$`,
+		entry.Message)
+	assert.Empty(t, entry.Fields)
+}
+
+func TestRequestLogger_CommonLogFormat_WithoutQuery(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewInMemory(log.LevelInfo)
+	config := middleware.DefaultRequestLoggerConfig()
+	config.Format = middleware.AccessLogFormatCommon
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	middleware.RequestLogger(logger, &config)(handler).ServeHTTP(httptest.NewRecorder(), newAccessLogRequest())
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].Message, `"GET /orders HTTP/1.1" 200 -`)
+}
+
+func TestRequestLogger_CombinedLogFormat(t *testing.T) {
+	t.Parallel()
+
+	entry := serveWithFormat(t, middleware.AccessLogFormatCombined, newAccessLogRequest())
+
+	assert.Regexp(t, `" 201 13 "https://example\.com/" "curl/8\.0 \\"quoted\\""$`, entry.Message)
+}
+
+func TestRequestLogger_ECSFormat(t *testing.T) {
+	t.Parallel()
+
+	entry := serveWithFormat(t, middleware.AccessLogFormatECS, newAccessLogRequest())
+	assert.Equal(t, middleware.DefaultRequestLogMessage, entry.Message)
+
+	expectedStrings := map[string]string{
+		"http.version":          "1.1",
+		"http.request.method":   http.MethodGet,
+		"url.path":              "/orders",
+		"url.query":             "page=1&access_token=[REDACTED]",
+		"client.address":        "192.0.2.1",
+		"user_agent.original":   `curl/8.0 "quoted"`,
+		"http.request.referrer": "https://example.com/",
+	}
+	for key, value := range expectedStrings {
+		field, ok := entry.GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, value, field.String)
+	}
+
+	expectedIntegers := map[string]int64{
+		"http.response.status_code": http.StatusCreated,
+		"http.request.body.bytes":   0,
+		"http.response.body.bytes":  13,
+	}
+	for key, value := range expectedIntegers {
+		field, ok := entry.GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, value, field.Integer)
+	}
+
+	_, ok := entry.GetField("event.duration")
+	assert.True(t, ok)
+}
+
+func TestRequestLogger_ECSFormat_UnknownRequestSize(t *testing.T) {
+	t.Parallel()
+
+	req := newAccessLogRequest()
+	req.ContentLength = -1
+	entry := serveWithFormat(t, middleware.AccessLogFormatECS, req)
+
+	_, ok := entry.GetField("http.request.body.bytes")
+	assert.False(t, ok)
+}
+
+func TestRequestLogger_GCPFormat(t *testing.T) {
+	t.Parallel()
+
+	req := newAccessLogRequest()
+	req.Host = "api.example.com"
+	entry := serveWithFormat(t, middleware.AccessLogFormatGCP, req)
+	assert.Equal(t, middleware.DefaultRequestLogMessage, entry.Message)
+
+	httpRequest, ok := entry.GetField("httpRequest")
+	require.True(t, ok)
+	fields := map[string]log.Field{}
+	for _, f := range httpRequest.Fields() {
+		fields[f.Key] = f
+	}
+
+	expectedStrings := map[string]string{
+		"protocol":      "HTTP/1.1",
+		"requestMethod": http.MethodGet,
+		"requestUrl":    "http://api.example.com/orders?page=1&access_token=[REDACTED]",
+		"requestSize":   "0",
+		"responseSize":  "13",
+		"remoteIp":      "192.0.2.1",
+		"userAgent":     `curl/8.0 "quoted"`,
+		"referer":       "https://example.com/",
+	}
+	for key, value := range expectedStrings {
+		require.Contains(t, fields, key)
+		assert.Equal(t, value, fields[key].String)
+	}
+	assert.Equal(t, int64(http.StatusCreated), fields["status"].Integer)
+	assert.Regexp(t, `^\d+(\.\d+)?s$`, fields["latency"].String)
+}
+
+func TestRequestLogger_Format(t *testing.T) {
+	t.Parallel()
+
+	t.Run("case-insensitive", func(t *testing.T) {
+		t.Parallel()
+
+		entry := serveWithFormat(t, "Combined", newAccessLogRequest())
+		assert.Regexp(t, `" 201 13 "https://example\.com/" "curl/8\.0 \\"quoted\\""$`, entry.Message)
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		config := middleware.DefaultRequestLoggerConfig()
+		config.Format = "clf"
+		assert.PanicsWithError(t, `middleware: unknown access log format "clf"`, func() {
+			middleware.RequestLogger(log.NewInMemory(log.LevelInfo), &config)
+		})
+	})
+}
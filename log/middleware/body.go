@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// DefaultMaxBodySize is the default maximum number of bytes logged from the request and response bodies.
+const DefaultMaxBodySize = 4 << 10
+
+// defaultBodyContentTypes are the media types of the bodies logged by default.
+var defaultBodyContentTypes = []string{
+	"application/json",
+	"application/xml",
+	"application/x-www-form-urlencoded",
+	"text/*",
+}
+
+// DefaultBodyContentTypes returns the media types of the bodies logged by default: JSON, XML,
+// form and text bodies.
+func DefaultBodyContentTypes() []string {
+	return append([]string(nil), defaultBodyContentTypes...)
+}
+
+// bodyCapture holds the first bytes of a request or response body, up to its limit.
+type bodyCapture struct {
+	limit     int
+	data      []byte
+	truncated bool
+}
+
+// Write appends the bytes to the captured data, up to the limit of the capture. It never fails,
+// so that it can be used as the body writer of an httputil.ResponseWriter.
+func (c *bodyCapture) Write(p []byte) (int, error) {
+	n := min(len(p), c.limit-len(c.data))
+	c.data = append(c.data, p[:n]...)
+	if n < len(p) {
+		c.truncated = true
+	}
+	return len(p), nil
+}
+
+// replayBody is a request body that replays the bytes read before the handler, followed by the rest of the body.
+type replayBody struct {
+	io.Reader
+	io.Closer
+}
+
+// captureRequestBody reads the first bytes of the request body, up to the limit, and restores them, so that
+// the handler reads the whole body. It returns nil if the body is empty or its content type is not logged.
+func captureRequestBody(r *http.Request, limit int, contentTypes []string) *bodyCapture {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil
+	}
+	contentType := r.Header.Get("Content-Type")
+	if contentType != "" && !matchContentType(contentType, contentTypes) {
+		return nil
+	}
+
+	// One more byte than the limit is read, to know if the body is truncated
+	data, _ := io.ReadAll(io.LimitReader(r.Body, int64(limit)+1))
+	r.Body = &replayBody{
+		Reader: io.MultiReader(bytes.NewReader(data), r.Body),
+		Closer: r.Body,
+	}
+
+	if contentType == "" && !matchContentType(http.DetectContentType(data), contentTypes) {
+		return nil
+	}
+	c := &bodyCapture{limit: limit}
+	_, _ = c.Write(data)
+	return c
+}
+
+// filterResponseBody returns the captured response body, or nil if it is empty or its content type is not logged.
+func filterResponseBody(body *bodyCapture, header http.Header, contentTypes []string) *bodyCapture {
+	if body == nil || len(body.data) == 0 {
+		return nil
+	}
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		contentType = http.DetectContentType(body.data)
+	}
+	if !matchContentType(contentType, contentTypes) {
+		return nil
+	}
+	return body
+}
+
+// matchContentType reports whether the media type of the content type is one of the allowed media types.
+// Allowed media types ending with "/*", like "text/*", match all the subtypes of the type.
+func matchContentType(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, a := range allowed {
+		a = strings.ToLower(a)
+		if a == mediaType || (strings.HasSuffix(a, "/*") && strings.HasPrefix(mediaType, a[:len(a)-1])) {
+			return true
+		}
+	}
+	return false
+}
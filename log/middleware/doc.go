@@ -1,2 +1,3 @@
-// Package middleware provides a set of middleware functions for HTTP servers, related to logging, like a request logger.
+// Package middleware provides a set of middleware functions for HTTP servers and clients, related to logging, like
+// a request logger, a request id propagator, a panic recoverer and a logging transport for http.Client.
 package middleware
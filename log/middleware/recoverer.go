@@ -0,0 +1,74 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"runtime/debug"
+
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/brpaz/lib-go/httputil"
+	"github.com/brpaz/lib-go/log"
+)
+
+// Recoverer is a middleware that recovers from panics in the handlers. The panic is logged at the
+// Error level, with its stack trace and the request method and path, and recorded as an error in
+// the active span. If the handler did not write the response yet, a JSON error response with
+// the 500 status code is written.
+//
+// The trace ids are added by the context extractors of the logger. Panics with http.ErrAbortHandler
+// are not recovered, since they are used to abort the response.
+//
+// Usage:
+//
+//	logger, _ := log.New(log.WithAdapter("zap"))
+//	handler := middleware.Recoverer(logger)(mux)
+//	http.ListenAndServe(":8080", middleware.RequestLogger(logger, nil)(handler))
+func Recoverer(logger log.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			lrw := httputil.NewResponseWriter(rw)
+			defer func() {
+				rec := recover()
+				if rec == nil {
+					return
+				}
+				if rec == http.ErrAbortHandler {
+					panic(rec)
+				}
+
+				err := panicError(rec)
+				ctx := r.Context()
+
+				span := trace.SpanFromContext(ctx)
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+
+				logger.Error(ctx, "panic recovered",
+					log.Err(err),
+					log.String("method", r.Method),
+					log.String("path", r.URL.Path),
+					log.Stacktrace(string(debug.Stack())),
+				)
+
+				// The status code can only be written if the response was not started
+				if !lrw.WroteHeader() {
+					httputil.JSONError(rw, http.StatusInternalServerError, "")
+				}
+			}()
+			next.ServeHTTP(lrw.Writer(), r)
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// panicError converts the value passed to panic to an error.
+func panicError(rec any) error {
+	if err, ok := rec.(error); ok {
+		return fmt.Errorf("panic: %w", err)
+	}
+	return errors.New("panic: " + fmt.Sprint(rec))
+}
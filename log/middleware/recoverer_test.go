@@ -0,0 +1,136 @@
+package middleware_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/brpaz/lib-go/log"
+	"github.com/brpaz/lib-go/log/middleware"
+)
+
+func TestRecoverer_Panic(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := middleware.Recoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	}))
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	ctx, span := tracer.Start(context.Background(), "request")
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/orders", nil).WithContext(ctx)
+	require.NotPanics(t, func() {
+		handler.ServeHTTP(rr, req)
+	})
+	span.End()
+
+	assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	assert.JSONEq(t, `{"status": 500, "error": "Internal Server Error"}`, rr.Body.String())
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, log.LevelError.String(), entries[0].Level)
+	assert.Equal(t, "panic recovered", entries[0].Message)
+
+	for key, expected := range map[string]string{"method": http.MethodPost, "path": "/orders", "traceId": span.SpanContext().TraceID().String()} {
+		field, ok := entries[0].GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, expected, field.String)
+	}
+	stack, ok := entries[0].GetField("stacktrace")
+	require.True(t, ok)
+	assert.Contains(t, stack.String, "TestRecoverer_Panic")
+	errField, ok := entries[0].GetField("error")
+	require.True(t, ok)
+	assert.EqualError(t, errField.Interface.(error), "panic: something went wrong")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.Equal(t, codes.Error, spans[0].Status().Code)
+	require.Len(t, spans[0].Events(), 1)
+	assert.Equal(t, "exception", spans[0].Events()[0].Name)
+}
+
+func TestRecoverer_PanicAfterWrite(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	cause := errors.New("stream closed")
+	handler := middleware.Recoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic(cause)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusAccepted, rr.Code)
+	assert.Empty(t, rr.Body.String())
+
+	require.Len(t, logger.Entries(), 1)
+	errField, _ := logger.Entries()[0].GetField("error")
+	assert.ErrorIs(t, errField.Interface.(error), cause)
+}
+
+func TestRecoverer_NoPanic(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := middleware.Recoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, http.StatusNoContent, rr.Code)
+	assert.Empty(t, logger.Entries())
+}
+
+func TestRecoverer_AbortHandler(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := middleware.Recoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+	assert.Empty(t, logger.Entries())
+}
+
+func TestRecoverer_SingleStacktrace(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger, err := log.New(log.WithRotatingFile(log.FileOutputOpts{Path: path}))
+	require.NoError(t, err)
+
+	handler := middleware.Recoverer(logger)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders", nil))
+	require.NoError(t, log.Close(logger))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// The entry only has the stack trace of the panic, and not the one added by the logger
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, 1, strings.Count(string(content), `"stacktrace"`))
+	assert.Contains(t, entry["stacktrace"], "TestRecoverer_SingleStacktrace.func1")
+}
@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+const (
+	// DefaultRequestIDHeader is the header used to read and write the request id.
+	DefaultRequestIDHeader = "X-Request-ID"
+	// RequestIDField is the key of the log field with the request id.
+	RequestIDField = "request_id"
+)
+
+// maxRequestIDLength is the maximum length of the request ids accepted from the incoming requests.
+const maxRequestIDLength = 128
+
+type requestIDCtxKeyType struct{}
+
+var requestIDCtxKey = requestIDCtxKeyType{}
+
+// RequestIDConfig holds configuration options for the request id middleware.
+type RequestIDConfig struct {
+	// Header is the header used to read the request id from the request and to write it to
+	// the response. Defaults to DefaultRequestIDHeader.
+	Header string
+	// Generator generates the request id when the request does not have a valid one.
+	// Defaults to a random 128-bit id, encoded in hex.
+	Generator func() string
+}
+
+// RequestID is a middleware that reads the request id from the request header, or generates a new one
+// when it is missing or invalid, and sets it in the response header. The id is stored in the context
+// of the request, and added to the log fields of the context (see log.ContextWithFields), so that
+// every entry logged with the request context includes it, under the RequestIDField key.
+//
+// To include the request id in the entries of the RequestLogger, RequestID must wrap it.
+//
+// Usage:
+//
+//	logger, _ := log.New(log.WithAdapter("zap"))
+//	handler := middleware.RequestID(nil)(middleware.RequestLogger(logger, nil)(mux))
+//	http.ListenAndServe(":8080", handler)
+func RequestID(config *RequestIDConfig) func(http.Handler) http.Handler {
+	header := DefaultRequestIDHeader
+	generate := generateRequestID
+	if config != nil {
+		if config.Header != "" {
+			header = config.Header
+		}
+		if config.Generator != nil {
+			generate = config.Generator
+		}
+	}
+
+	return func(next http.Handler) http.Handler {
+		fn := func(rw http.ResponseWriter, r *http.Request) {
+			id := r.Header.Get(header)
+			if !validRequestID(id) {
+				id = generate()
+			}
+
+			rw.Header().Set(header, id)
+			next.ServeHTTP(rw, r.WithContext(ContextWithRequestID(r.Context(), id)))
+		}
+
+		return http.HandlerFunc(fn)
+	}
+}
+
+// ContextWithRequestID returns a new context with the request id, which is also added to the log fields of the context.
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	ctx = log.ContextWithFields(ctx, log.String(RequestIDField, id))
+	return context.WithValue(ctx, requestIDCtxKey, id)
+}
+
+// RequestIDFromContext returns the request id stored in the context, or an empty string if there is none.
+func RequestIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(requestIDCtxKey).(string)
+	return id
+}
+
+// generateRequestID generates a random 128-bit request id, encoded in hex.
+func generateRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// validRequestID reports whether the request id received from the client can be used. To prevent
+// log injection, only non-empty ids with up to maxRequestIDLength printable ASCII characters
+// and without spaces are accepted.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := range len(id) {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,95 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+	"github.com/brpaz/lib-go/log/middleware"
+)
+
+func TestRequestID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		incoming string
+		expected string
+	}{
+		{name: "propagates incoming id", incoming: "abc-123", expected: "abc-123"},
+		{name: "generates missing id", incoming: "", expected: ""},
+		{name: "replaces invalid id", incoming: "abc\nlevel=error", expected: ""},
+		{name: "replaces long id", incoming: strings.Repeat("a", 129), expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var ctxID string
+			handler := middleware.RequestID(nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctxID = middleware.RequestIDFromContext(r.Context())
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.incoming != "" {
+				req.Header.Set(middleware.DefaultRequestIDHeader, tt.incoming)
+			}
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			responseID := rr.Header().Get(middleware.DefaultRequestIDHeader)
+			if tt.expected != "" {
+				assert.Equal(t, tt.expected, responseID)
+			} else {
+				assert.Len(t, responseID, 32)
+			}
+			assert.Equal(t, responseID, ctxID)
+		})
+	}
+}
+
+func TestRequestID_CustomConfig(t *testing.T) {
+	t.Parallel()
+	handler := middleware.RequestID(&middleware.RequestIDConfig{
+		Header:    "X-Correlation-ID",
+		Generator: func() string { return "generated" },
+	})(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	assert.Equal(t, "generated", rr.Header().Get("X-Correlation-ID"))
+	assert.Empty(t, rr.Header().Get(middleware.DefaultRequestIDHeader))
+}
+
+func TestRequestID_LogFields(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		log.FromContext(log.ContextWithLogger(r.Context(), logger)).Info(r.Context(), "handling request")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(middleware.DefaultRequestIDHeader, "abc-123")
+	middleware.RequestID(nil)(middleware.RequestLogger(logger, nil)(handler)).ServeHTTP(httptest.NewRecorder(), req)
+
+	entries := logger.Entries()
+	require.Len(t, entries, 2)
+	for _, entry := range entries {
+		field, ok := entry.GetField(middleware.RequestIDField)
+		require.True(t, ok, "Field '%s' not found in entry '%s'", middleware.RequestIDField, entry.Message)
+		assert.Equal(t, "abc-123", field.String)
+	}
+}
+
+func TestRequestIDFromContext_Empty(t *testing.T) {
+	t.Parallel()
+	assert.Empty(t, middleware.RequestIDFromContext(context.Background()))
+}
@@ -1,15 +1,19 @@
 package middleware
 
 import (
-	"io"
 	"net/http"
+	"path"
 	"strings"
 	"time"
 
+	"github.com/brpaz/lib-go/httputil"
 	"github.com/brpaz/lib-go/log"
 )
 
 // LoggableResponseWriter is a wrapper around http.ResponseWriter that captures the status code, so that we can retrieve it in the logging middleware.
+//
+// Deprecated: LoggableResponseWriter hides the optional interfaces of the wrapped writer, like http.Flusher
+// and http.Hijacker. Use httputil.ResponseWriter instead.
 type LoggableResponseWriter struct {
 	http.ResponseWriter
 	StatusCode   int
@@ -33,12 +37,31 @@ func (lrw *LoggableResponseWriter) Write(p []byte) (int, error) {
 	return n, err
 }
 
+// DefaultRequestLogMessage is the message of the entries logged by the RequestLogger.
+const DefaultRequestLogMessage = "incoming request"
+
+// RequestLogEntry is an entry about to be logged by the RequestLogger. It is passed to the
+// RequestLoggerConfig.Hook, which can change its level, message and fields.
+type RequestLogEntry struct {
+	// Status is the status code of the response.
+	Status int
+	// Duration is the time taken to handle the request.
+	Duration time.Duration
+	// Level is the level of the entry.
+	Level log.Level
+	// Message is the message of the entry. Defaults to DefaultRequestLogMessage.
+	Message string
+	// Fields are the fields of the entry, after the redaction of sensitive data.
+	Fields []log.Field
+}
+
 // RequestLoggerConfig holds configuration options for logging middleware.
 type RequestLoggerConfig struct {
 	LogMethod             bool
 	LogPath               bool
 	LogStatus             bool
 	LogDuration           bool
+	LogTimeToFirstByte    bool
 	LogRemoteAddr         bool
 	LogUserAgent          bool
 	LogRequestSize        bool
@@ -46,7 +69,35 @@ type RequestLoggerConfig struct {
 	LogRequestQueryParams bool
 	LogRequestHeaders     bool
 	LogRequestBody        bool
+	LogResponseHeaders    bool
 	LogResponseBody       bool
+	// Format is the format of the entries: AccessLogFormatDefault, AccessLogFormatCommon,
+	// AccessLogFormatCombined, AccessLogFormatECS or AccessLogFormatGCP. The options above select
+	// the fields of the default, ECS and GCP formats, and are ignored by the Common and Combined
+	// formats, which log a line with fixed content, except for LogRequestQueryParams, which includes
+	// the query in the request line. The format is case-insensitive, and RequestLogger panics if it
+	// is unknown. Defaults to AccessLogFormatDefault.
+	Format string
+	// MaxBodySize is the maximum number of bytes logged from the request and response bodies. Larger
+	// bodies are truncated, and the request_body_truncated or response_body_truncated field is added.
+	// Defaults to DefaultMaxBodySize.
+	MaxBodySize int
+	// BodyContentTypes are the media types of the request and response bodies that are logged, like
+	// "application/json". Media types ending with "/*", like "text/*", match all the subtypes of the type.
+	// When the body does not have a content type, it is detected from its content.
+	// Defaults to DefaultBodyContentTypes.
+	BodyContentTypes []string
+	// Redactor masks sensitive data, like credentials in headers, query parameters and bodies.
+	// When nil, the redactor with the default redaction options is used.
+	Redactor *log.Redactor
+	// SkipPaths are the paths of the requests that are not logged, like "/health". The paths
+	// can be patterns, like "/static/*", using the syntax of path.Match.
+	SkipPaths []string
+	// SlowRequestThreshold is the duration above which the requests are logged at the Warn level,
+	// if their status does not require a higher level. Disabled when zero.
+	SlowRequestThreshold time.Duration
+	// Hook is called before logging each request, and can change the level, message and fields of the entry.
+	Hook func(r *http.Request, entry *RequestLogEntry)
 }
 
 // defaultRequestLoggerConfig provides the default logging configuration.
@@ -64,12 +115,19 @@ var defaultRequestLoggerConfig = RequestLoggerConfig{
 	LogResponseSize:       true,
 }
 
+// defaultRedactor is used when the config does not specify a redactor.
+var defaultRedactor = log.NewRedactor(log.DefaultRedactionOpts())
+
 func DefaultRequestLoggerConfig() RequestLoggerConfig {
 	return defaultRequestLoggerConfig
 }
 
 // RequestLogger is a middleware that logs the http request.
 //
+// The requests with a 5xx status are logged at the Error level, the ones with a 4xx status or slower than
+// the SlowRequestThreshold at the Warn level, and the others at the Info level. The requests matching
+// the SkipPaths are not logged. It panics if the Format of the config is unknown.
+//
 // Usage:
 //
 //	package main
@@ -92,23 +150,80 @@ func RequestLogger(logger log.Logger, config *RequestLoggerConfig) func(http.Han
 		config = &defaultRequestLoggerConfig
 	}
 
+	// An unknown format would silently log the entries in the default format
+	format, err := parseAccessLogFormat(config.Format)
+	if err != nil {
+		panic(err)
+	}
+	if format != config.Format {
+		normalized := *config
+		normalized.Format = format
+		config = &normalized
+	}
+
+	maxBodySize := config.MaxBodySize
+	if maxBodySize <= 0 {
+		maxBodySize = DefaultMaxBodySize
+	}
+	bodyContentTypes := config.BodyContentTypes
+	if len(bodyContentTypes) == 0 {
+		bodyContentTypes = defaultBodyContentTypes
+	}
+
 	return func(next http.Handler) http.Handler {
 		fn := func(rw http.ResponseWriter, r *http.Request) {
+			if skipPath(r.URL.Path, config.SkipPaths) {
+				next.ServeHTTP(rw, r)
+				return
+			}
+
 			t1 := time.Now()
-			lrw := &LoggableResponseWriter{rw, http.StatusOK, 0, false}
+
+			// The request body is captured before the handler consumes it
+			var requestBody, responseBody *bodyCapture
+			if config.LogRequestBody {
+				requestBody = captureRequestBody(r, maxBodySize, bodyContentTypes)
+			}
+			var opts []httputil.ResponseWriterOpt
+			if config.LogResponseBody {
+				responseBody = &bodyCapture{limit: maxBodySize}
+				opts = append(opts, httputil.WithBodyWriter(responseBody))
+			}
+			lrw := httputil.NewResponseWriter(rw, opts...)
+
 			defer func() {
-				logFields := buildLogFieldsFromConfig(r, lrw, config, t1)
+				duration := time.Since(t1)
+				status := responseStatus(lrw)
+				msg, fields := buildAccessLogEntry(accessLogRecord{
+					r:            r,
+					lrw:          lrw,
+					start:        t1,
+					duration:     duration,
+					requestBody:  requestBody,
+					responseBody: filterResponseBody(responseBody, lrw.Header(), bodyContentTypes),
+				}, config)
+				entry := &RequestLogEntry{
+					Status:   status,
+					Duration: duration,
+					Level:    requestLogLevel(status, duration, config.SlowRequestThreshold),
+					Message:  msg,
+					Fields:   fields,
+				}
+				if config.Hook != nil {
+					config.Hook(r, entry)
+				}
+
 				// Log the request details
-				logger.Info(r.Context(), "incoming request", logFields...)
+				logger.Log(r.Context(), entry.Level, entry.Message, entry.Fields...)
 			}()
-			next.ServeHTTP(lrw, r)
+			next.ServeHTTP(lrw.Writer(), r)
 		}
 
 		return http.HandlerFunc(fn)
 	}
 }
 
-func buildLogFieldsFromConfig(r *http.Request, lrw *LoggableResponseWriter, config *RequestLoggerConfig, reqStartTime time.Time) []log.Field {
+func buildLogFieldsFromConfig(r *http.Request, lrw *httputil.ResponseWriter, config *RequestLoggerConfig, duration time.Duration, requestBody, responseBody *bodyCapture) []log.Field {
 	fields := []log.Field{}
 
 	// Conditionally log based on the configuration
@@ -119,16 +234,19 @@ func buildLogFieldsFromConfig(r *http.Request, lrw *LoggableResponseWriter, conf
 		fields = append(fields, log.String("path", r.URL.Path))
 	}
 	if config.LogStatus {
-		fields = append(fields, log.Int("status", lrw.StatusCode))
+		fields = append(fields, log.Int("status", responseStatus(lrw)))
 	}
 	if config.LogDuration {
-		fields = append(fields, log.String("duration", time.Since(reqStartTime).String()))
+		fields = append(fields, log.String("duration", duration.String()))
+	}
+	if config.LogTimeToFirstByte {
+		fields = append(fields, log.String("time_to_first_byte", lrw.TimeToFirstByte().String()))
 	}
 	if config.LogRequestSize {
 		fields = append(fields, log.Int("request_size", int(r.ContentLength)))
 	}
 	if config.LogResponseSize {
-		fields = append(fields, log.Int("response_size", lrw.ResponseSize))
+		fields = append(fields, log.Int64("response_size", lrw.BytesWritten()))
 	}
 
 	if config.LogRequestQueryParams {
@@ -143,18 +261,86 @@ func buildLogFieldsFromConfig(r *http.Request, lrw *LoggableResponseWriter, conf
 		fields = append(fields, log.String("user_agent", r.UserAgent()))
 	}
 
-	if config.LogRequestBody {
-		body, _ := io.ReadAll(r.Body)
+	fields = append(fields, bodyFields("request_body", requestBody)...)
 
-		fields = append(fields, log.String("request_body", string(body)))
+	if config.LogRequestHeaders {
+		fields = append(fields, headerFields("header_", r.Header)...)
 	}
 
-	if config.LogRequestHeaders {
-		for key, values := range r.Header {
-			for _, value := range values {
-				fields = append(fields, log.String("header_"+strings.ToLower(key), value))
-			}
+	if config.LogResponseHeaders {
+		fields = append(fields, headerFields("response_header_", lrw.Header())...)
+	}
+
+	fields = append(fields, bodyFields("response_body", responseBody)...)
+
+	// Mask sensitive data, like the Authorization header or credentials in the body
+	return redactorOrDefault(config.Redactor).Redact(fields...)
+}
+
+// headerFields returns a field for each value of the headers, with the lowercase header name prefixed by prefix as key.
+func headerFields(prefix string, header http.Header) []log.Field {
+	var fields []log.Field
+	for key, values := range header {
+		for _, value := range values {
+			fields = append(fields, log.String(prefix+strings.ToLower(key), value))
+		}
+	}
+	return fields
+}
+
+// redactorOrDefault returns the redactor, or the redactor with the default redaction options if it is nil.
+func redactorOrDefault(redactor *log.Redactor) *log.Redactor {
+	if redactor == nil {
+		return defaultRedactor
+	}
+	return redactor
+}
+
+// responseStatus returns the status code of the response. When the handler did not write
+// anything, net/http responds with 200.
+func responseStatus(lrw *httputil.ResponseWriter) int {
+	if !lrw.WroteHeader() {
+		return http.StatusOK
+	}
+	return lrw.Status()
+}
+
+// requestLogLevel returns the level of the entry of a request, based on its status and duration.
+func requestLogLevel(status int, duration, slowThreshold time.Duration) log.Level {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return log.LevelError
+	case status >= http.StatusBadRequest:
+		return log.LevelWarn
+	case slowThreshold > 0 && duration > slowThreshold:
+		return log.LevelWarn
+	default:
+		return log.LevelInfo
+	}
+}
+
+// skipPath reports whether the path matches one of the paths or patterns that are not logged.
+func skipPath(p string, skipPaths []string) bool {
+	for _, pattern := range skipPaths {
+		if pattern == p {
+			return true
 		}
+		if matched, _ := path.Match(pattern, p); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// bodyFields returns the fields with the captured body, and whether it was truncated.
+func bodyFields(key string, body *bodyCapture) []log.Field {
+	if body == nil {
+		return nil
+	}
+
+	fields := []log.Field{log.String(key, string(body.data))}
+	if body.truncated {
+		fields = append(fields, log.Bool(key+"_truncated", true))
 	}
 	return fields
 }
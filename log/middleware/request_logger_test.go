@@ -2,9 +2,11 @@ package middleware_test
 
 import (
 	"bytes"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -15,6 +17,7 @@ import (
 
 func TestLoggableResponseWriter_WriteHeader(t *testing.T) {
 	recorder := httptest.NewRecorder()
+	//nolint:staticcheck
 	lrw := &middleware.LoggableResponseWriter{ResponseWriter: recorder}
 
 	lrw.WriteHeader(http.StatusCreated)
@@ -23,6 +26,7 @@ func TestLoggableResponseWriter_WriteHeader(t *testing.T) {
 
 func TestLoggableResponseWriter_Write(t *testing.T) {
 	recorder := httptest.NewRecorder()
+	//nolint:staticcheck
 	lrw := &middleware.LoggableResponseWriter{ResponseWriter: recorder}
 	data := []byte("Hello, World!")
 
@@ -178,3 +182,238 @@ func TestRequestLogger_WithFullRequestFields(t *testing.T) {
 	assert.True(t, ok, "Field 'request_body' not found in log entry")
 	assert.Equal(t, `{"test": "test"}`, requestBodyField.String)
 }
+
+func TestRequestLogger_RedactsSensitiveData(t *testing.T) {
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	loggerConfig := &middleware.RequestLoggerConfig{
+		LogRequestHeaders:     true,
+		LogRequestQueryParams: true,
+	}
+
+	requestLogger := middleware.RequestLogger(logger, loggerConfig)
+	server := httptest.NewServer(requestLogger(handler))
+	defer server.Close()
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL+"?page=1&access_token=abc123", nil)
+	req.Header.Set("Authorization", "Bearer abc123")
+	req.Header.Set("X-Api-Key", "abc123")
+	req.Header.Set("X-Test-Header", "test-header")
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+
+	for _, key := range []string{"header_authorization", "header_x-api-key"} {
+		field, ok := logs[0].GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, log.DefaultRedactionMask, field.String)
+	}
+
+	testHeaderField, ok := logs[0].GetField("header_x-test-header")
+	require.True(t, ok)
+	assert.Equal(t, "test-header", testHeaderField.String)
+
+	queryField, ok := logs[0].GetField("query")
+	require.True(t, ok)
+	assert.Equal(t, "page=1&access_token=[REDACTED]", queryField.String)
+}
+
+func TestRequestLogger_CapturesBodies(t *testing.T) {
+	logger := log.NewInMemory(log.LevelInfo)
+	var handlerBody string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		handlerBody = string(body)
+
+		w.Header().Set("Content-Type", "application/json")
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "abc123"})
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id": 1, "name": "order"}`))
+	})
+
+	loggerConfig := &middleware.RequestLoggerConfig{
+		LogRequestBody:     true,
+		LogResponseBody:    true,
+		LogResponseHeaders: true,
+		MaxBodySize:        10,
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(`{"name": "order"}`)))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	middleware.RequestLogger(logger, loggerConfig)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, `{"name": "order"}`, handlerBody)
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+
+	expectedFields := map[string]string{
+		"request_body":                 `{"name": "`,
+		"response_body":                `{"id": 1, `,
+		"response_header_content-type": "application/json",
+		"response_header_set-cookie":   log.DefaultRedactionMask,
+	}
+	for key, value := range expectedFields {
+		field, ok := logs[0].GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, value, field.String)
+	}
+
+	for _, key := range []string{"request_body_truncated", "response_body_truncated"} {
+		field, ok := logs[0].GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, int64(1), field.Integer)
+	}
+}
+
+func TestRequestLogger_SkipsBodiesOfOtherContentTypes(t *testing.T) {
+	logger := log.NewInMemory(log.LevelInfo)
+	image := []byte("\x89PNG\r\n\x1a\n")
+	var handlerBody []byte
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handlerBody, _ = io.ReadAll(r.Body)
+		_, _ = w.Write(image)
+	})
+
+	loggerConfig := &middleware.RequestLoggerConfig{
+		LogRequestBody:   true,
+		LogResponseBody:  true,
+		BodyContentTypes: []string{"text/*"},
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/images", bytes.NewReader(image))
+	req.Header.Set("Content-Type", "image/png")
+	middleware.RequestLogger(logger, loggerConfig)(handler).ServeHTTP(httptest.NewRecorder(), req)
+
+	assert.Equal(t, image, handlerBody)
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	_, ok := logs[0].GetField("request_body")
+	assert.False(t, ok)
+	_, ok = logs[0].GetField("response_body")
+	assert.False(t, ok)
+}
+
+func TestRequestLogger_Levels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   int
+		delay    time.Duration
+		expected log.Level
+	}{
+		{name: "success", status: http.StatusOK, expected: log.LevelInfo},
+		{name: "client error", status: http.StatusNotFound, expected: log.LevelWarn},
+		{name: "server error", status: http.StatusServiceUnavailable, expected: log.LevelError},
+		{name: "slow request", status: http.StatusOK, delay: 20 * time.Millisecond, expected: log.LevelWarn},
+		{name: "slow server error", status: http.StatusInternalServerError, delay: 20 * time.Millisecond, expected: log.LevelError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			logger := log.NewInMemory(log.LevelInfo)
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				time.Sleep(tt.delay)
+				w.WriteHeader(tt.status)
+			})
+
+			config := middleware.DefaultRequestLoggerConfig()
+			config.SlowRequestThreshold = 10 * time.Millisecond
+			middleware.RequestLogger(logger, &config)(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+			logs := logger.Entries()
+			require.Len(t, logs, 1)
+			assert.Equal(t, tt.expected.String(), logs[0].Level)
+			assert.Equal(t, middleware.DefaultRequestLogMessage, logs[0].Message)
+		})
+	}
+}
+
+func TestRequestLogger_SkipPaths(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	config := middleware.DefaultRequestLoggerConfig()
+	config.SkipPaths = []string{"/health", "/static/*"}
+	requestLogger := middleware.RequestLogger(logger, &config)(handler)
+
+	for _, p := range []string{"/health", "/static/app.js", "/static/css/app.css", "/orders"} {
+		rr := httptest.NewRecorder()
+		requestLogger.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, p, nil))
+		assert.Equal(t, http.StatusOK, rr.Code)
+	}
+
+	var paths []string
+	for _, entry := range logger.Entries() {
+		field, _ := entry.GetField("path")
+		paths = append(paths, field.String)
+	}
+	assert.Equal(t, []string{"/static/css/app.css", "/orders"}, paths)
+}
+
+func TestRequestLogger_Hook(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	config := &middleware.RequestLoggerConfig{
+		LogPath: true,
+		Hook: func(r *http.Request, entry *middleware.RequestLogEntry) {
+			assert.Equal(t, http.StatusNotFound, entry.Status)
+			entry.Level = log.LevelInfo
+			entry.Message = r.Method + " " + r.URL.Path
+			entry.Fields = append(entry.Fields, log.String("route", "/orders/{id}"))
+		},
+	}
+	middleware.RequestLogger(logger, config)(handler).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	assert.Equal(t, log.LevelInfo.String(), logs[0].Level)
+	assert.Equal(t, "GET /orders/1", logs[0].Message)
+	route, ok := logs[0].GetField("route")
+	require.True(t, ok)
+	assert.Equal(t, "/orders/{id}", route.String)
+}
+
+func TestRequestLogger_PreservesFlusher(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: event\n\n"))
+		flusher.Flush()
+	})
+
+	config := &middleware.RequestLoggerConfig{LogStatus: true, LogTimeToFirstByte: true, LogResponseSize: true}
+	rr := httptest.NewRecorder()
+	middleware.RequestLogger(logger, config)(handler).ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/events", nil))
+
+	assert.True(t, rr.Flushed)
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	for _, key := range []string{"status", "time_to_first_byte", "response_size"} {
+		_, ok := logs[0].GetField(key)
+		assert.True(t, ok, "Field '%s' not found in log entry", key)
+	}
+}
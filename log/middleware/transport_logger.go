@@ -0,0 +1,239 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// DefaultTransportLogMessage is the message of the entries logged by the TransportLogger.
+const DefaultTransportLogMessage = "outgoing request"
+
+type retryAttemptCtxKeyType struct{}
+
+var retryAttemptCtxKey = retryAttemptCtxKeyType{}
+
+// TransportLoggerConfig holds configuration options for the client logging transport.
+type TransportLoggerConfig struct {
+	LogMethod          bool
+	LogURL             bool
+	LogStatus          bool
+	LogDuration        bool
+	LogRequestSize     bool
+	LogResponseSize    bool
+	LogRetryAttempt    bool
+	LogRequestHeaders  bool
+	LogResponseHeaders bool
+	// Redactor masks sensitive data, like credentials in headers and query parameters.
+	// When nil, the redactor with the default redaction options is used.
+	Redactor *log.Redactor
+	// SlowRequestThreshold is the duration above which the requests are logged at the Warn level,
+	// if their status does not require a higher level. Disabled when zero.
+	SlowRequestThreshold time.Duration
+	// Hook is called before logging each request, and can change the level, message and fields of the entry.
+	// The status of the entry is 0 when the request failed without a response.
+	Hook func(r *http.Request, entry *RequestLogEntry)
+}
+
+// defaultTransportLoggerConfig provides the default logging configuration of the transport.
+var defaultTransportLoggerConfig = TransportLoggerConfig{
+	LogMethod:       true,
+	LogURL:          true,
+	LogStatus:       true,
+	LogDuration:     true,
+	LogRequestSize:  true,
+	LogResponseSize: true,
+	LogRetryAttempt: true,
+}
+
+func DefaultTransportLoggerConfig() TransportLoggerConfig {
+	return defaultTransportLoggerConfig
+}
+
+// ContextWithRetryAttempt returns a new context with the number of the retry attempt of a request, starting
+// at 1 for the first retry. Retry logic wrapping the http.Client can set it, so that the TransportLogger
+// logs the attempt in the retry_attempt field.
+func ContextWithRetryAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, retryAttemptCtxKey, attempt)
+}
+
+// RetryAttemptFromContext returns the number of the retry attempt stored in the context, or 0 if there is none.
+func RetryAttemptFromContext(ctx context.Context) int {
+	if ctx == nil {
+		return 0
+	}
+	attempt, _ := ctx.Value(retryAttemptCtxKey).(int)
+	return attempt
+}
+
+// TransportLogger is a middleware for http.Client transports that logs the outgoing requests.
+//
+// Like in the RequestLogger, the requests with a 5xx status or that failed without a response are logged
+// at the Error level, the ones with a 4xx status or slower than the SlowRequestThreshold at the Warn level,
+// and the others at the Info level. The entries are logged with the context of the request, so they include
+// the fields extracted from it, like the trace and request ids.
+//
+// When the response size is logged, the entries of the requests with a response are logged when the
+// response body is closed, which the callers of http.Client must do, and the response size is the
+// number of bytes read from the body, since the Content-Length header is unknown for the chunked and
+// the decompressed responses. The request size is the one of the Content-Length header, or the
+// number of bytes read from the request body by the transport when it is unknown.
+//
+// Usage:
+//
+//	logger, _ := log.New(log.WithAdapter("zap"))
+//	client := &http.Client{
+//		Transport: middleware.TransportLogger(logger, nil)(http.DefaultTransport),
+//	}
+func TransportLogger(logger log.Logger, config *TransportLoggerConfig) func(http.RoundTripper) http.RoundTripper {
+	// Use default config if none provided
+	if config == nil {
+		config = &defaultTransportLoggerConfig
+	}
+
+	return func(next http.RoundTripper) http.RoundTripper {
+		if next == nil {
+			next = http.DefaultTransport
+		}
+
+		return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			// The size of the request bodies without a Content-Length is counted while they are sent
+			outgoing := r
+			var requestBody *countingBody
+			if config.LogRequestSize && r.Body != nil && r.Body != http.NoBody && r.ContentLength <= 0 {
+				requestBody = &countingBody{ReadCloser: r.Body}
+				outgoing = r.WithContext(r.Context())
+				outgoing.Body = requestBody
+			}
+
+			t1 := time.Now()
+			resp, err := next.RoundTrip(outgoing)
+			duration := time.Since(t1)
+
+			status := 0
+			level := log.LevelError
+			if err == nil {
+				status = resp.StatusCode
+				level = requestLogLevel(status, duration, config.SlowRequestThreshold)
+			}
+
+			logRequest := func(responseSize int64) {
+				requestSize := r.ContentLength
+				if requestBody != nil {
+					requestSize = requestBody.n.Load()
+				}
+
+				entry := &RequestLogEntry{
+					Status:   status,
+					Duration: duration,
+					Level:    level,
+					Message:  DefaultTransportLogMessage,
+					Fields:   buildTransportLogFields(r, resp, err, config, duration, requestSize, responseSize),
+				}
+				if config.Hook != nil {
+					config.Hook(r, entry)
+				}
+
+				logger.Log(r.Context(), entry.Level, entry.Message, entry.Fields...)
+			}
+
+			// The body of the switching protocols responses is the connection, which is not counted
+			if err != nil || !config.LogResponseSize || resp.StatusCode == http.StatusSwitchingProtocols {
+				responseSize := int64(-1)
+				if resp != nil {
+					responseSize = resp.ContentLength
+				}
+				logRequest(responseSize)
+				return resp, err
+			}
+
+			resp.Body = &countingBody{ReadCloser: resp.Body, onClose: logRequest}
+			return resp, nil
+		})
+	}
+}
+
+// roundTripperFunc is an adapter to use a function as an http.RoundTripper.
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+// RoundTrip calls the function.
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// countingBody is a request or response body that counts the bytes read from it. When closed, it calls
+// onClose with the number of bytes read, once.
+type countingBody struct {
+	io.ReadCloser
+	n       atomic.Int64
+	once    sync.Once
+	onClose func(n int64)
+}
+
+// Read reads from the body, counting the bytes read.
+func (b *countingBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	b.n.Add(int64(n))
+	return n, err
+}
+
+// Close closes the body and then calls onClose, the first time it is called.
+func (b *countingBody) Close() error {
+	err := b.ReadCloser.Close()
+	if b.onClose != nil {
+		b.once.Do(func() {
+			b.onClose(b.n.Load())
+		})
+	}
+	return err
+}
+
+// buildTransportLogFields builds the fields of the entry of an outgoing request, with the sizes of
+// the request and response bodies.
+func buildTransportLogFields(r *http.Request, resp *http.Response, err error, config *TransportLoggerConfig, duration time.Duration, requestSize, responseSize int64) []log.Field {
+	fields := []log.Field{}
+
+	// Conditionally log based on the configuration
+	if config.LogMethod {
+		fields = append(fields, log.String("method", r.Method))
+	}
+	if config.LogURL {
+		// Redacted masks the password of the URL, while the redactor masks the sensitive query parameters
+		fields = append(fields, log.String("url", r.URL.Redacted()))
+	}
+	if config.LogStatus && resp != nil {
+		fields = append(fields, log.Int("status", resp.StatusCode))
+	}
+	if config.LogDuration {
+		fields = append(fields, log.String("duration", duration.String()))
+	}
+	if config.LogRequestSize {
+		fields = append(fields, log.Int64("request_size", requestSize))
+	}
+	if config.LogResponseSize && resp != nil {
+		fields = append(fields, log.Int64("response_size", responseSize))
+	}
+	if attempt := RetryAttemptFromContext(r.Context()); config.LogRetryAttempt && attempt > 0 {
+		fields = append(fields, log.Int("retry_attempt", attempt))
+	}
+
+	if config.LogRequestHeaders {
+		fields = append(fields, headerFields("header_", r.Header)...)
+	}
+
+	if config.LogResponseHeaders && resp != nil {
+		fields = append(fields, headerFields("response_header_", resp.Header)...)
+	}
+
+	if err != nil {
+		fields = append(fields, log.Err(err))
+	}
+
+	// Mask sensitive data, like the Authorization header or credentials in the query parameters
+	return redactorOrDefault(config.Redactor).Redact(fields...)
+}
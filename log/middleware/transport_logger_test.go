@@ -0,0 +1,175 @@
+package middleware_test
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+	"github.com/brpaz/lib-go/log/middleware"
+)
+
+// failingTransport is a transport that always fails.
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestTransportLogger_UsesDefaultConfig_OnNilConfig(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("Hello, World!"))
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: middleware.TransportLogger(logger, nil)(nil)}
+	resp, err := client.Post(server.URL+"/orders?page=1&access_token=abc123", "text/plain", strings.NewReader("order"))
+	require.NoError(t, err)
+
+	// The request is logged when the response body is closed
+	assert.Empty(t, logger.Entries())
+	_, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	assert.Equal(t, middleware.DefaultTransportLogMessage, logs[0].Message)
+	assert.Equal(t, log.LevelInfo.String(), logs[0].Level)
+	assert.Len(t, logs[0].Fields, 6)
+
+	expectedFields := map[string]any{
+		"method":        http.MethodPost,
+		"url":           server.URL + "/orders?page=1&access_token=[REDACTED]",
+		"status":        int64(http.StatusOK),
+		"request_size":  int64(5),
+		"response_size": int64(13),
+	}
+	for key, value := range expectedFields {
+		field, ok := logs[0].GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		if s, isString := value.(string); isString {
+			assert.Equal(t, s, field.String)
+		} else {
+			assert.Equal(t, value, field.Integer)
+		}
+	}
+	_, ok := logs[0].GetField("duration")
+	assert.True(t, ok)
+}
+
+func TestTransportLogger_ServerErrorWithRetryAttempt(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Set-Cookie", "session=abc123")
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	config := &middleware.TransportLoggerConfig{
+		LogStatus:          true,
+		LogRetryAttempt:    true,
+		LogRequestHeaders:  true,
+		LogResponseHeaders: true,
+	}
+	client := &http.Client{Transport: middleware.TransportLogger(logger, config)(http.DefaultTransport)}
+
+	ctx := middleware.ContextWithRetryAttempt(context.Background(), 2)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer abc123")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	assert.Equal(t, log.LevelError.String(), logs[0].Level)
+
+	attempt, ok := logs[0].GetField("retry_attempt")
+	require.True(t, ok)
+	assert.Equal(t, int64(2), attempt.Integer)
+
+	for _, key := range []string{"header_authorization", "response_header_set-cookie"} {
+		field, ok := logs[0].GetField(key)
+		require.True(t, ok, "Field '%s' not found in log entry", key)
+		assert.Equal(t, log.DefaultRedactionMask, field.String)
+	}
+}
+
+func TestTransportLogger_TransportError(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+
+	var hookStatus int
+	config := middleware.DefaultTransportLoggerConfig()
+	config.Hook = func(r *http.Request, entry *middleware.RequestLogEntry) {
+		hookStatus = entry.Status
+		entry.Message = "upstream call failed"
+	}
+	client := &http.Client{Transport: middleware.TransportLogger(logger, &config)(failingTransport{})}
+
+	_, err := client.Get("http://upstream.local/orders")
+	require.Error(t, err)
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	assert.Equal(t, log.LevelError.String(), logs[0].Level)
+	assert.Equal(t, "upstream call failed", logs[0].Message)
+	assert.Zero(t, hookStatus)
+
+	errField, ok := logs[0].GetField("error")
+	require.True(t, ok)
+	assert.EqualError(t, errField.Interface.(error), "connection refused")
+	_, ok = logs[0].GetField("status")
+	assert.False(t, ok)
+}
+
+func TestTransportLogger_UnknownSizes(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+
+		// A compressed response, without Content-Length, which the transport decompresses
+		w.Header().Set("Content-Encoding", "gzip")
+		gw := gzip.NewWriter(w)
+		_, _ = gw.Write([]byte(`{"status":"created"}`))
+		_ = gw.Close()
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: middleware.TransportLogger(logger, nil)(http.DefaultTransport)}
+
+	// The request body has no known length, so it is sent chunked
+	req, err := http.NewRequest(http.MethodPost, server.URL, struct{ io.Reader }{strings.NewReader(`{"id":1}`)})
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, int64(-1), resp.ContentLength)
+	assert.Equal(t, `{"status":"created"}`, string(body))
+
+	logs := logger.Entries()
+	require.Len(t, logs, 1)
+	requestSize, _ := logs[0].GetField("request_size")
+	assert.Equal(t, int64(8), requestSize.Integer)
+	responseSize, _ := logs[0].GetField("response_size")
+	assert.Equal(t, int64(len(body)), responseSize.Integer)
+}
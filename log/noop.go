@@ -1,6 +1,9 @@
 package log
 
-import "context"
+import (
+	"context"
+	"os"
+)
 
 // NopLogger is a no-op logger implementation.
 type NopLogger struct{}
@@ -22,6 +25,22 @@ func (l *NopLogger) Error(ctx context.Context, msg string, fields ...Field) {}
 // Debug does nothing for the no-op logger.
 func (l *NopLogger) Debug(ctx context.Context, msg string, fields ...Field) {}
 
+// Trace does nothing for the no-op logger.
+func (l *NopLogger) Trace(ctx context.Context, msg string, fields ...Field) {}
+
+// Panic does not log anything, but still panics with the message.
+func (l *NopLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	panic(msg)
+}
+
+// Fatal does not log anything, but still calls os.Exit(1).
+func (l *NopLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	os.Exit(1)
+}
+
+// Log does nothing for the no-op logger.
+func (l *NopLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {}
+
 // SetLevel does nothing for the no-op logger.
 func (l *NopLogger) SetLevel(level Level) error {
 	return nil
@@ -37,6 +56,11 @@ func (l *NopLogger) With(fields ...Field) Logger {
 	return l
 }
 
+// Named returns the same no-op logger instance.
+func (l *NopLogger) Named(name string) Logger {
+	return l
+}
+
 // Sync does nothing for the no-op logger.
 func (l *NopLogger) Sync() error {
 	return nil
@@ -0,0 +1,340 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"time"
+
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+)
+
+// OtelScopeName is the default instrumentation scope of the records emitted by the OtelAdapter.
+const OtelScopeName = "github.com/brpaz/lib-go/log"
+
+// otelLoggerNameKey is the key of the attribute with the name of named loggers.
+const otelLoggerNameKey = "logger"
+
+// OtelAdapter is a logger that emits the entries as records through the OpenTelemetry Logs API,
+// so they are exported with the traces and correlated with them by trace and span id.
+type OtelAdapter struct {
+	OtelL otellog.Logger
+	// provider is the provider of the options, or nil when the global provider is used.
+	provider   otellog.LoggerProvider
+	level      *levelVar
+	name       string
+	fields     []Field
+	registry   *LevelRegistry
+	extractors []ContextExtractor
+}
+
+// OtelLoggerOpts specifies options to configure the OpenTelemetry logger instance.
+type OtelLoggerOpts struct {
+	Level Level
+	// LoggerProvider provides the OpenTelemetry logger that emits the records.
+	// Defaults to the global logger provider, set by tracing.SetupOtelSDK with tracing.WithLogs.
+	LoggerProvider otellog.LoggerProvider
+	// ScopeName is the instrumentation scope of the records. Defaults to OtelScopeName.
+	ScopeName string
+	// ContextExtractors extract the fields added to every entry from the context.
+	// Defaults to FieldsFromContext only when nil, since the records carry the trace and span ids of the context,
+	// while an empty slice disables the extraction.
+	ContextExtractors []ContextExtractor
+}
+
+// defaultOtelLoggerOpts defines the default options for the OpenTelemetry logger.
+var defaultOtelLoggerOpts = OtelLoggerOpts{
+	Level:     LevelInfo,
+	ScopeName: OtelScopeName,
+}
+
+// DefaultOtelLoggerOpts returns a copy of the default OpenTelemetry logger options.
+func DefaultOtelLoggerOpts() OtelLoggerOpts {
+	return defaultOtelLoggerOpts
+}
+
+// NewOtel creates a new logger that emits the entries through the OpenTelemetry Logs API.
+// Usage:
+//
+//	shutdown, err := tracing.SetupOtelSDK(ctx, tracing.WithServiceName("api"), tracing.WithServiceVersion("1.0.0"),
+//		tracing.WithOtlpGrpcExporter(), tracing.WithLogs())
+//	logger := log.NewOtel(log.DefaultOtelLoggerOpts())
+func NewOtel(opts OtelLoggerOpts) *OtelAdapter {
+	// The global provider is resolved when the logger is synced, since the SDK provider
+	// may be set after the logger is created.
+	provider := opts.LoggerProvider
+	if provider == nil {
+		provider = global.GetLoggerProvider()
+	}
+
+	scopeName := opts.ScopeName
+	if scopeName == "" {
+		scopeName = OtelScopeName
+	}
+
+	extractors := opts.ContextExtractors
+	if extractors == nil {
+		extractors = []ContextExtractor{FieldsFromContext}
+	}
+
+	return &OtelAdapter{
+		OtelL:      provider.Logger(scopeName),
+		provider:   opts.LoggerProvider,
+		level:      newLevelVar(opts.Level),
+		registry:   NewLevelRegistry(),
+		extractors: extractors,
+	}
+}
+
+// log emits a record with the message and fields, if the level is enabled.
+func (l *OtelAdapter) log(ctx context.Context, lvl Level, msg string, fields ...Field) {
+	l.write(ctx, time.Time{}, lvl, msg, fields)
+}
+
+// writeEntry writes an entry of a logger that wraps this logger.
+func (l *OtelAdapter) writeEntry(ctx context.Context, e entry) {
+	l.write(ctx, e.time, e.level, e.msg, e.fields)
+	if e.terminate {
+		terminate(l, e.level, e.msg)
+	}
+}
+
+// write emits a record with the message and fields, if the level is enabled. The timestamp of the
+// record is t, or the current time if t is zero, while the observed timestamp is always the current time.
+// The trace and span ids of the context are added to the record by the OpenTelemetry SDK.
+func (l *OtelAdapter) write(ctx context.Context, t time.Time, lvl Level, msg string, fields []Field) {
+	if lvl < l.level.Level() {
+		return
+	}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	severity := toOtelSeverity(lvl)
+	if !l.OtelL.Enabled(ctx, otellog.EnabledParameters{Severity: severity}) {
+		return
+	}
+
+	now := time.Now()
+	if t.IsZero() {
+		t = now
+	}
+	var record otellog.Record
+	record.SetTimestamp(t)
+	record.SetObservedTimestamp(now)
+	record.SetSeverity(severity)
+	record.SetSeverityText(lvl.String())
+	record.SetBody(otellog.StringValue(msg))
+	if l.name != "" {
+		record.AddAttributes(otellog.String(otelLoggerNameKey, l.name))
+	}
+	record.AddAttributes(toOtelAttributes(slices.Concat(l.fields, fields, extractContextFields(ctx, l.extractors)))...)
+
+	l.OtelL.Emit(ctx, record)
+}
+
+// Info logs a message at the info level.
+func (l *OtelAdapter) Info(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelInfo, msg, fields...)
+}
+
+// Warn logs a message at the warning level.
+func (l *OtelAdapter) Warn(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelWarn, msg, fields...)
+}
+
+// Error logs a message at the error level.
+func (l *OtelAdapter) Error(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelError, msg, fields...)
+}
+
+// Debug logs a message at the debug level.
+func (l *OtelAdapter) Debug(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelDebug, msg, fields...)
+}
+
+// Trace logs a message at the trace level.
+func (l *OtelAdapter) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelTrace, msg, fields...)
+}
+
+// Panic logs a message at the panic level and then panics.
+func (l *OtelAdapter) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelPanic, msg, fields...)
+	panic(msg)
+}
+
+// Fatal logs a message at the fatal level, flushes the logger and then calls os.Exit(1).
+func (l *OtelAdapter) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, LevelFatal, msg, fields...)
+	_ = l.Sync()
+	os.Exit(1)
+}
+
+// Log logs a message at the given level, without panicking or exiting.
+func (l *OtelAdapter) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.log(ctx, level, msg, fields...)
+}
+
+// SetLevel updates the logging level dynamically.
+func (l *OtelAdapter) SetLevel(level Level) error {
+	l.level.Set(level)
+	return nil
+}
+
+// GetLevel retrieves the current logging level.
+func (l *OtelAdapter) GetLevel() Level {
+	return l.level.Level()
+}
+
+// With creates a new logger instance with additional fields.
+func (l *OtelAdapter) With(fields ...Field) Logger {
+	return &OtelAdapter{
+		OtelL:      l.OtelL,
+		provider:   l.provider,
+		level:      l.level,
+		name:       l.name,
+		fields:     slices.Concat(l.fields, fields),
+		registry:   l.registry,
+		extractors: l.extractors,
+	}
+}
+
+// Named creates a new logger instance with the given name appended to the logger name,
+// and a level of its own, starting at the current level of the parent logger.
+func (l *OtelAdapter) Named(name string) Logger {
+	fullName := joinLoggerName(l.name, name)
+	return l.registry.register(fullName, func(registered Logger) Logger {
+		level := newLevelVar(l.level.Level())
+		if named, ok := registered.(*OtelAdapter); ok {
+			level = named.level
+		}
+
+		return &OtelAdapter{
+			OtelL:      l.OtelL,
+			provider:   l.provider,
+			level:      level,
+			name:       fullName,
+			fields:     l.fields,
+			registry:   l.registry,
+			extractors: l.extractors,
+		}
+	})
+}
+
+// Registry returns the registry of the named loggers created from this logger.
+func (l *OtelAdapter) Registry() *LevelRegistry {
+	return l.registry
+}
+
+// Sync flushes the records buffered by the logger provider, when it supports flushing, like the
+// logger provider of the OpenTelemetry SDK. When the logger uses the global logger provider, the
+// provider set when Sync is called is flushed.
+//
+// Sync does nothing for the providers that can't be flushed, so the application must shut down
+// its SDK provider before exiting to export the buffered records, like with the shutdown function
+// returned by tracing.SetupOtelSDK.
+func (l *OtelAdapter) Sync() error {
+	provider := l.provider
+	if provider == nil {
+		provider = global.GetLoggerProvider()
+	}
+
+	if flusher, ok := provider.(interface{ ForceFlush(context.Context) error }); ok {
+		return flusher.ForceFlush(context.Background())
+	}
+	return nil
+}
+
+// toOtelAttributes converts log fields to OpenTelemetry attributes, adding the fields that describe the errors.
+func toOtelAttributes(fields []Field) []otellog.KeyValue {
+	fields = expandErrorFields(fields)
+	attrs := make([]otellog.KeyValue, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == ErrorType && f.Interface == nil {
+			continue
+		}
+		attrs = append(attrs, otellog.KeyValue{Key: f.Key, Value: toOtelValue(f)})
+	}
+	return attrs
+}
+
+// toOtelValue converts the value of a single log field to an OpenTelemetry value.
+// Durations and times are converted to strings, like in the zap adapter.
+func toOtelValue(f Field) otellog.Value {
+	switch f.Type {
+	case StringType:
+		return otellog.StringValue(f.String)
+	case BoolType:
+		return otellog.BoolValue(f.Integer == 1)
+	case Int64Type:
+		return otellog.Int64Value(f.Integer)
+	case Uint64Type:
+		if v := uint64(f.Integer); v > math.MaxInt64 {
+			return otellog.StringValue(strconv.FormatUint(v, 10))
+		}
+		return otellog.Int64Value(f.Integer)
+	case Float64Type, Float32Type:
+		return otellog.Float64Value(f.Float)
+	case DurationType:
+		return otellog.StringValue(time.Duration(f.Integer).String())
+	case TimeType:
+		t, _ := f.Interface.(time.Time)
+		return otellog.StringValue(t.Format(time.RFC3339Nano))
+	case ErrorType:
+		err, _ := f.Interface.(error)
+		return otellog.StringValue(err.Error())
+	case StringerType:
+		return otellog.StringValue(stringerValue(f.Interface))
+	case GroupType, ObjectType:
+		return otellog.MapValue(toOtelAttributes(f.Fields())...)
+	default:
+		return toOtelAnyValue(f.Interface)
+	}
+}
+
+// toOtelAnyValue converts an arbitrary value to an OpenTelemetry value.
+// Values without an equivalent OpenTelemetry type are formatted as strings.
+func toOtelAnyValue(value any) otellog.Value {
+	switch v := value.(type) {
+	case nil:
+		return otellog.Value{}
+	case []byte:
+		return otellog.BytesValue(v)
+	case []string:
+		values := make([]otellog.Value, len(v))
+		for i, s := range v {
+			values[i] = otellog.StringValue(s)
+		}
+		return otellog.SliceValue(values...)
+	default:
+		return otellog.StringValue(fmt.Sprint(v))
+	}
+}
+
+// toOtelSeverity converts a Level to an OpenTelemetry severity.
+// The Panic and Fatal levels are mapped to the fatal severities.
+func toOtelSeverity(level Level) otellog.Severity {
+	switch level {
+	case LevelTrace:
+		return otellog.SeverityTrace
+	case LevelDebug:
+		return otellog.SeverityDebug
+	case LevelInfo:
+		return otellog.SeverityInfo
+	case LevelWarn:
+		return otellog.SeverityWarn
+	case LevelError:
+		return otellog.SeverityError
+	case LevelPanic:
+		return otellog.SeverityFatal1
+	case LevelFatal:
+		return otellog.SeverityFatal4
+	default:
+		return otellog.SeverityInfo
+	}
+}
@@ -0,0 +1,166 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	otellog "go.opentelemetry.io/otel/log"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// recordingExporter is a sdklog.Exporter that keeps the exported records in memory.
+type recordingExporter struct {
+	mu      sync.Mutex
+	records []sdklog.Record
+}
+
+func (e *recordingExporter) Export(_ context.Context, records []sdklog.Record) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, r := range records {
+		e.records = append(e.records, r.Clone())
+	}
+	return nil
+}
+
+func (e *recordingExporter) Shutdown(context.Context) error { return nil }
+
+func (e *recordingExporter) ForceFlush(context.Context) error { return nil }
+
+func (e *recordingExporter) Records() []sdklog.Record {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return append([]sdklog.Record(nil), e.records...)
+}
+
+// createTestOtelLogger creates an OtelAdapter that exports the records to the returned exporter.
+func createTestOtelLogger(level log.Level) (*log.OtelAdapter, *recordingExporter) {
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewSimpleProcessor(exporter)))
+
+	opts := log.DefaultOtelLoggerOpts()
+	opts.Level = level
+	opts.LoggerProvider = provider
+	return log.NewOtel(opts), exporter
+}
+
+// otelAttributes returns the attributes of the record, by key.
+func otelAttributes(record sdklog.Record) map[string]otellog.Value {
+	attrs := make(map[string]otellog.Value)
+	record.WalkAttributes(func(kv otellog.KeyValue) bool {
+		attrs[kv.Key] = kv.Value
+		return true
+	})
+	return attrs
+}
+
+func TestOtelAdapter_Emit(t *testing.T) {
+	t.Parallel()
+	logger, exporter := createTestOtelLogger(log.LevelInfo)
+
+	tracer := sdktrace.NewTracerProvider().Tracer("test")
+	ctx, span := tracer.Start(context.Background(), "operation")
+	defer span.End()
+
+	logger.With(log.String("service", "api")).Error(ctx, "request failed",
+		log.Int("status", 500),
+		log.Err(errors.New("boom")),
+		log.Group("user", log.String("id", "42")),
+	)
+
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	record := records[0]
+
+	assert.Equal(t, "request failed", record.Body().AsString())
+	assert.Equal(t, otellog.SeverityError, record.Severity())
+	assert.Equal(t, "error", record.SeverityText())
+	assert.Equal(t, log.OtelScopeName, record.InstrumentationScope().Name)
+	assert.Equal(t, span.SpanContext().TraceID(), record.TraceID())
+	assert.Equal(t, span.SpanContext().SpanID(), record.SpanID())
+
+	attrs := otelAttributes(record)
+	assert.Equal(t, "api", attrs["service"].AsString())
+	assert.Equal(t, int64(500), attrs["status"].AsInt64())
+	assert.Equal(t, "boom", attrs["error"].AsString())
+	assert.Equal(t, []otellog.KeyValue{otellog.String("id", "42")}, attrs["user"].AsMap())
+	assert.NotContains(t, attrs, "trace_id", "trace ids are carried by the record")
+}
+
+func TestOtelAdapter_Levels(t *testing.T) {
+	t.Parallel()
+	logger, exporter := createTestOtelLogger(log.LevelWarn)
+	ctx := context.Background()
+
+	logger.Info(ctx, "discarded")
+	logger.Warn(ctx, "kept")
+	logger.Log(ctx, log.LevelFatal, "fatal without exiting")
+	assert.PanicsWithValue(t, "panic message", func() {
+		logger.Panic(ctx, "panic message")
+	})
+
+	records := exporter.Records()
+	require.Len(t, records, 3)
+	assert.Equal(t, otellog.SeverityWarn, records[0].Severity())
+	assert.Equal(t, otellog.SeverityFatal4, records[1].Severity())
+	assert.Equal(t, otellog.SeverityFatal1, records[2].Severity())
+	assert.Equal(t, "panic", records[2].SeverityText())
+}
+
+func TestOtelAdapter_Named(t *testing.T) {
+	t.Parallel()
+	logger, exporter := createTestOtelLogger(log.LevelInfo)
+	ctx := context.Background()
+
+	dbLogger := logger.Named("db")
+	require.NoError(t, dbLogger.SetLevel(log.LevelDebug))
+
+	dbLogger.Debug(ctx, "db debug message")
+	logger.Debug(ctx, "discarded debug message")
+
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "db", otelAttributes(records[0])["logger"].AsString())
+	assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	assert.Equal(t, []string{"db"}, log.RegistryOf(logger).Names())
+}
+
+func TestOtelAdapter_Sync_GlobalProvider(t *testing.T) {
+	// The logger is created before the SDK provider is set as the global provider
+	logger := log.NewOtel(log.DefaultOtelLoggerOpts())
+
+	exporter := &recordingExporter{}
+	provider := sdklog.NewLoggerProvider(sdklog.WithProcessor(sdklog.NewBatchProcessor(exporter, sdklog.WithExportInterval(time.Hour))))
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	global.SetLoggerProvider(provider)
+
+	logger.Info(context.Background(), "buffered message")
+	assert.Empty(t, exporter.Records())
+
+	require.NoError(t, logger.Sync())
+	records := exporter.Records()
+	require.Len(t, records, 1)
+	assert.Equal(t, "buffered message", records[0].Body().AsString())
+}
+
+func TestNew_OtelAdapter(t *testing.T) {
+	t.Parallel()
+
+	logger, err := log.New(log.WithAdapter(log.AdapterOtel), log.WithLevel(log.LevelDebug))
+	require.NoError(t, err)
+
+	assert.IsType(t, &log.OtelAdapter{}, logger)
+	assert.Equal(t, log.LevelDebug, logger.GetLevel())
+	assert.NoError(t, logger.Sync())
+}
@@ -0,0 +1,174 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// Constants for the available output types.
+const (
+	OutputStdout = "stdout"
+	OutputStderr = "stderr"
+	OutputFile   = "file"
+)
+
+// allowedOutputs lists the valid output types.
+var allowedOutputs = []string{OutputStdout, OutputStderr, OutputFile}
+
+var (
+	ErrInvalidOutput     = errors.New("invalid log output")
+	ErrMissingOutputPath = errors.New("log output file path is required")
+)
+
+// Output defines a destination where log entries are written.
+type Output struct {
+	Type string
+	File FileOutputOpts
+}
+
+// FileOutputOpts specifies the options for a rotating file output.
+// A zero value for any of the limits means the limit is not enforced, except for MaxSizeMB,
+// which defaults to 100 megabytes.
+type FileOutputOpts struct {
+	// Path is the file to write logs to. Rotated files are kept in the same directory.
+	Path string
+	// MaxSizeMB is the maximum size in megabytes of the log file before it gets rotated.
+	MaxSizeMB int
+	// MaxAgeDays is the maximum number of days to retain rotated log files.
+	MaxAgeDays int
+	// MaxBackups is the maximum number of rotated log files to retain.
+	MaxBackups int
+	// Compress determines if the rotated log files should be compressed using gzip.
+	Compress bool
+	// LocalTime determines if the timestamps in rotated file names use the local time instead of UTC.
+	LocalTime bool
+}
+
+// Validate checks if the output configuration is valid.
+func (o Output) Validate() error {
+	if !slices.Contains(allowedOutputs, o.Type) {
+		return fmt.Errorf("%w: %s. Allowed values are %v", ErrInvalidOutput, o.Type, allowedOutputs)
+	}
+	if o.Type == OutputFile && o.File.Path == "" {
+		return ErrMissingOutputPath
+	}
+	return nil
+}
+
+// WithStdout adds the standard output as a destination for the log entries.
+func WithStdout() LoggerOpt {
+	return WithOutput(Output{Type: OutputStdout})
+}
+
+// WithStderr adds the standard error as a destination for the log entries.
+func WithStderr() LoggerOpt {
+	return WithOutput(Output{Type: OutputStderr})
+}
+
+// WithRotatingFile adds a file as a destination for the log entries.
+// The file is rotated based on the size and age limits defined in the options.
+func WithRotatingFile(opts FileOutputOpts) LoggerOpt {
+	return WithOutput(Output{Type: OutputFile, File: opts})
+}
+
+// WithOutput adds one or more destinations for the log entries.
+// When no outputs are configured, logs are written to the standard error.
+func WithOutput(outputs ...Output) LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.Outputs = append(o.Outputs, outputs...)
+	}
+}
+
+// validateOutputs checks if all the provided outputs are valid.
+func validateOutputs(outputs []Output) error {
+	for _, output := range outputs {
+		if err := output.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newOutputWriters creates a writer for each of the provided outputs, and returns the closers of the
+// writers that must be closed to release their resources, like the files of the file outputs.
+// Defaults to the standard error when no outputs are provided.
+func newOutputWriters(outputs []Output) ([]io.Writer, []io.Closer, error) {
+	if len(outputs) == 0 {
+		return []io.Writer{os.Stderr}, nil, nil
+	}
+
+	writers := make([]io.Writer, 0, len(outputs))
+	var closers []io.Closer
+	for _, output := range outputs {
+		if err := output.Validate(); err != nil {
+			return nil, nil, err
+		}
+
+		switch output.Type {
+		case OutputStdout:
+			writers = append(writers, os.Stdout)
+		case OutputStderr:
+			writers = append(writers, os.Stderr)
+		case OutputFile:
+			file := newRotatingFileWriter(output.File)
+			writers = append(writers, file)
+			closers = append(closers, file)
+		}
+	}
+	return writers, closers, nil
+}
+
+// closeOutputs closes the writers of the outputs, returning the errors of all of them.
+func closeOutputs(closers []io.Closer) error {
+	var errs []error
+	for _, closer := range closers {
+		if err := closer.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// newRotatingFileWriter creates a writer that rotates the log file based on the provided options.
+func newRotatingFileWriter(opts FileOutputOpts) *lumberjack.Logger {
+	return &lumberjack.Logger{
+		Filename:   opts.Path,
+		MaxSize:    opts.MaxSizeMB,
+		MaxAge:     opts.MaxAgeDays,
+		MaxBackups: opts.MaxBackups,
+		Compress:   opts.Compress,
+		LocalTime:  opts.LocalTime,
+	}
+}
+
+// colorEnabled reports whether colored output can be used, which requires all the writers to
+// be terminals. Colors can also be disabled with the NO_COLOR environment variable.
+func colorEnabled(writers []io.Writer) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	for _, w := range writers {
+		if !isTerminal(w) {
+			return false
+		}
+	}
+	return len(writers) > 0
+}
+
+// isTerminal reports whether the writer is a terminal.
+func isTerminal(w io.Writer) bool {
+	f, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
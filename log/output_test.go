@@ -0,0 +1,145 @@
+package log_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+func TestOutput_Validate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		output  log.Output
+		wantErr error
+	}{
+		{name: "Stdout", output: log.Output{Type: log.OutputStdout}},
+		{name: "Stderr", output: log.Output{Type: log.OutputStderr}},
+		{name: "File", output: log.Output{Type: log.OutputFile, File: log.FileOutputOpts{Path: "app.log"}}},
+		{name: "FileWithoutPath", output: log.Output{Type: log.OutputFile}, wantErr: log.ErrMissingOutputPath},
+		{name: "InvalidType", output: log.Output{Type: "invalid"}, wantErr: log.ErrInvalidOutput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := tt.output.Validate()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestOutputOpts(t *testing.T) {
+	t.Parallel()
+
+	opts := log.LoggerOpts{}
+	log.WithStdout()(&opts)
+	log.WithStderr()(&opts)
+	log.WithRotatingFile(log.FileOutputOpts{Path: "app.log", MaxSizeMB: 10, Compress: true})(&opts)
+
+	require.Len(t, opts.Outputs, 3)
+	assert.Equal(t, log.OutputStdout, opts.Outputs[0].Type)
+	assert.Equal(t, log.OutputStderr, opts.Outputs[1].Type)
+	assert.Equal(t, log.OutputFile, opts.Outputs[2].Type)
+	assert.Equal(t, "app.log", opts.Outputs[2].File.Path)
+	assert.True(t, opts.Outputs[2].File.Compress)
+}
+
+func TestNew_WithRotatingFile(t *testing.T) {
+	t.Parallel()
+
+	adapters := []string{log.AdapterZap, log.AdapterSlog}
+
+	for _, adapter := range adapters {
+		t.Run(adapter, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "app.log")
+
+			logger, err := log.New(
+				log.WithAdapter(adapter),
+				log.WithRotatingFile(log.FileOutputOpts{
+					Path:       path,
+					MaxSizeMB:  1,
+					MaxBackups: 2,
+					MaxAgeDays: 1,
+				}),
+			)
+			require.NoError(t, err)
+
+			logger.Info(context.Background(), "written to file", log.String("key", "value"))
+			require.NoError(t, logger.Sync())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assertContainsJSONField(t, string(content), "key", "value")
+		})
+	}
+}
+
+func TestClose_ClosesFileOutputs(t *testing.T) {
+	t.Parallel()
+
+	if _, err := os.Stat("/proc/self/fd"); err != nil {
+		t.Skip("the open files can only be listed on Linux")
+	}
+
+	for _, adapter := range []string{log.AdapterZap, log.AdapterSlog} {
+		t.Run(adapter, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "app.log")
+
+			logger, err := log.New(
+				log.WithAdapter(adapter),
+				log.WithRotatingFile(log.FileOutputOpts{Path: path}),
+				log.WithAsync(log.AsyncOpts{}),
+				log.WithRedaction(log.DefaultRedactionOpts()),
+			)
+			require.NoError(t, err)
+
+			logger.Named("db").Info(context.Background(), "written to file")
+			require.NoError(t, logger.Sync())
+			assert.True(t, isFileOpen(t, path))
+
+			require.NoError(t, log.Close(logger))
+			assert.False(t, isFileOpen(t, path))
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), "written to file")
+		})
+	}
+}
+
+// isFileOpen reports whether the process has a descriptor open for the file at path.
+func isFileOpen(t *testing.T, path string) bool {
+	t.Helper()
+
+	fds, err := os.ReadDir("/proc/self/fd")
+	require.NoError(t, err)
+	for _, fd := range fds {
+		if target, err := os.Readlink(filepath.Join("/proc/self/fd", fd.Name())); err == nil && target == path {
+			return true
+		}
+	}
+	return false
+}
+
+func TestNew_WithInvalidOutput(t *testing.T) {
+	t.Parallel()
+
+	_, err := log.New(log.WithOutput(log.Output{Type: log.OutputFile}))
+
+	require.Error(t, err)
+	assert.ErrorIs(t, err, log.ErrMissingOutputPath)
+}
@@ -0,0 +1,346 @@
+package log
+
+import (
+	"context"
+	"regexp"
+	"strings"
+)
+
+// DefaultRedactionMask is the value that replaces the sensitive data.
+const DefaultRedactionMask = "[REDACTED]"
+
+// Patterns matching common sensitive values.
+var (
+	PatternEmail       = regexp.MustCompile(`[a-zA-Z0-9._%+\-]+@[a-zA-Z0-9.\-]+\.[a-zA-Z]{2,}`)
+	PatternCardNumber  = regexp.MustCompile(`\b[3-6]\d{3}(?:[ \-]?\d{4}){2}[ \-]?\d{1,4}\b`)
+	PatternBearerToken = regexp.MustCompile(`(?i)\bbearer\s+[a-z0-9\-._~+/]+=*`)
+)
+
+// defaultRedactionKeys are the field keys that are redacted by default.
+var defaultRedactionKeys = []string{
+	"password",
+	"passwd",
+	"secret",
+	"token",
+	"access_token",
+	"refresh_token",
+	"api_key",
+	"api-key",
+	"x-api-key",
+	"apikey",
+	"authorization",
+	"proxy-authorization",
+	"cookie",
+	"set-cookie",
+}
+
+// RedactionOpts specifies which data should be redacted from the log fields.
+type RedactionOpts struct {
+	// Keys are the field keys whose values are always redacted. The comparison is case-insensitive
+	// and also matches keys ending with a separator (".", "_" or "-") followed by the key,
+	// so "authorization" matches "header_authorization".
+	Keys []string
+	// Patterns are matched against string values. The matched portions of the value are redacted.
+	Patterns []*regexp.Regexp
+	// Mask is the value that replaces the redacted data. Defaults to DefaultRedactionMask.
+	Mask string
+}
+
+// DefaultRedactionOpts returns redaction options covering common credentials, emails,
+// card numbers and bearer tokens.
+func DefaultRedactionOpts() RedactionOpts {
+	return RedactionOpts{
+		Keys:     append([]string(nil), defaultRedactionKeys...),
+		Patterns: []*regexp.Regexp{PatternEmail, PatternCardNumber, PatternBearerToken},
+		Mask:     DefaultRedactionMask,
+	}
+}
+
+// WithRedaction enables the redaction of sensitive data from the log fields.
+func WithRedaction(opts RedactionOpts) LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.Redaction = &opts
+	}
+}
+
+// Redactor masks sensitive data from log fields.
+type Redactor struct {
+	keys      map[string]struct{}
+	patterns  []*regexp.Regexp
+	keyValues *regexp.Regexp
+	mask      string
+}
+
+// NewRedactor creates a new Redactor with the provided options.
+func NewRedactor(opts RedactionOpts) *Redactor {
+	r := &Redactor{
+		keys:     make(map[string]struct{}, len(opts.Keys)),
+		patterns: opts.Patterns,
+		mask:     opts.Mask,
+	}
+	if r.mask == "" {
+		r.mask = DefaultRedactionMask
+	}
+
+	quotedKeys := make([]string, 0, len(opts.Keys))
+	for _, key := range opts.Keys {
+		r.keys[strings.ToLower(key)] = struct{}{}
+		quotedKeys = append(quotedKeys, regexp.QuoteMeta(key))
+	}
+
+	// Matches key/value pairs of the sensitive keys embedded in free text, like
+	// JSON documents ("password": "secret") or query strings (token=secret).
+	if len(quotedKeys) > 0 {
+		r.keyValues = regexp.MustCompile(`(?i)("?\b(?:` + strings.Join(quotedKeys, "|") + `)\b"?\s*[:=]\s*)("[^"]*"|[^\s&,;"}]+)`)
+	}
+
+	return r
+}
+
+// IsSensitiveKey reports whether the values of the given key must be redacted.
+func (r *Redactor) IsSensitiveKey(key string) bool {
+	key = strings.ToLower(key)
+	if _, ok := r.keys[key]; ok {
+		return true
+	}
+
+	for i := len(key) - 1; i > 0; i-- {
+		if key[i] == '.' || key[i] == '_' || key[i] == '-' {
+			if _, ok := r.keys[key[i+1:]]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// RedactString masks the sensitive data found in the given string.
+func (r *Redactor) RedactString(s string) string {
+	if r.keyValues != nil {
+		s = r.keyValues.ReplaceAllStringFunc(s, func(match string) string {
+			groups := r.keyValues.FindStringSubmatch(match)
+			if strings.HasPrefix(groups[2], `"`) {
+				return groups[1] + `"` + r.mask + `"`
+			}
+			return groups[1] + r.mask
+		})
+	}
+
+	for _, pattern := range r.patterns {
+		s = pattern.ReplaceAllString(s, r.mask)
+	}
+	return s
+}
+
+// Redact returns a copy of the fields with the sensitive data masked.
+// Fields with sensitive keys are replaced by a string field with the mask, while string
+// values are matched against the configured patterns. Groups and objects are redacted recursively,
+// like the maps with string keys and the slices of the Any fields.
+//
+// Error fields keep an error whose message is the redacted error message, followed by the
+// redacted fields describing the error, under the "<key>_causes" and "<key>_fields" keys (see Err).
+func (r *Redactor) Redact(fields ...Field) []Field {
+	if len(fields) == 0 {
+		return fields
+	}
+
+	redacted := make([]Field, 0, len(fields))
+	for _, f := range fields {
+		redacted = append(redacted, r.redactField(f))
+		if f.Type == ErrorType && !r.IsSensitiveKey(f.Key) {
+			redacted = append(redacted, r.Redact(errorDetails(f)...)...)
+		}
+	}
+	return redacted
+}
+
+// redactField masks the sensitive data of a single field.
+func (r *Redactor) redactField(f Field) Field {
+	if r.IsSensitiveKey(f.Key) {
+		return String(f.Key, r.mask)
+	}
+
+	switch f.Type {
+	case StringType:
+		f.String = r.RedactString(f.String)
+	case StringerType:
+		return String(f.Key, r.RedactString(stringerValue(f.Interface)))
+	case ErrorType:
+		if err, ok := f.Interface.(error); ok && err != nil {
+			f.Interface = redactedError{msg: r.RedactString(err.Error())}
+		}
+	case GroupType, ObjectType:
+		return Group(f.Key, r.Redact(f.Fields()...)...)
+	case AnyType:
+		f.Interface = r.redactValue(f.Interface)
+	}
+	return f
+}
+
+// redactedError is an error with a redacted message, which replaces the errors of the error fields.
+// It does not wrap the original error, whose chain holds the unredacted messages.
+type redactedError struct {
+	msg string
+}
+
+// Error returns the redacted message.
+func (e redactedError) Error() string {
+	return e.msg
+}
+
+// redactValue returns a copy of the maps with string keys and the slices of the value, with
+// the sensitive data masked. The values of the sensitive keys are replaced by the mask, while the
+// strings are matched against the configured patterns. Values of other types are returned as is.
+func (r *Redactor) redactValue(v any) any {
+	switch v := v.(type) {
+	case string:
+		return r.RedactString(v)
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, value := range v {
+			if r.IsSensitiveKey(key) {
+				redacted[key] = r.mask
+				continue
+			}
+			redacted[key] = r.redactValue(value)
+		}
+		return redacted
+	case map[string]string:
+		redacted := make(map[string]string, len(v))
+		for key, value := range v {
+			if r.IsSensitiveKey(key) {
+				redacted[key] = r.mask
+				continue
+			}
+			redacted[key] = r.RedactString(value)
+		}
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for i, value := range v {
+			redacted[i] = r.redactValue(value)
+		}
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for i, value := range v {
+			redacted[i] = r.RedactString(value)
+		}
+		return redacted
+	default:
+		return v
+	}
+}
+
+// RedactingLogger is a logger that masks sensitive data from the fields before writing them
+// to the underlying logger, including the fields the loggers of this package extract from the
+// context, like the fields attached with ContextWithFields. It can wrap any Logger implementation.
+type RedactingLogger struct {
+	logger   Logger
+	redactor *Redactor
+}
+
+// NewRedactingLogger creates a new logger that redacts the fields using the provided redactor.
+// Usage:
+//
+//	logger := log.NewRedactingLogger(zapLogger, log.NewRedactor(log.DefaultRedactionOpts()))
+//	logger.Info(ctx, "user logged in", log.String("password", "secret")) // password=[REDACTED]
+func NewRedactingLogger(logger Logger, redactor *Redactor) *RedactingLogger {
+	return &RedactingLogger{
+		logger:   logger,
+		redactor: redactor,
+	}
+}
+
+// Info logs an informational message with the redacted fields.
+func (l *RedactingLogger) Info(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelInfo, msg: msg, fields: fields, skip: 1})
+}
+
+// Warn logs a warning message with the redacted fields.
+func (l *RedactingLogger) Warn(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelWarn, msg: msg, fields: fields, skip: 1})
+}
+
+// Error logs an error message with the redacted fields.
+func (l *RedactingLogger) Error(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelError, msg: msg, fields: fields, skip: 1})
+}
+
+// Debug logs a debug message with the redacted fields.
+func (l *RedactingLogger) Debug(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelDebug, msg: msg, fields: fields, skip: 1})
+}
+
+// Trace logs a trace message with the redacted fields.
+func (l *RedactingLogger) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelTrace, msg: msg, fields: fields, skip: 1})
+}
+
+// Panic logs a panic message with the redacted fields and then panics.
+func (l *RedactingLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelPanic, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Fatal logs a fatal message with the redacted fields and then exits.
+func (l *RedactingLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelFatal, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Log logs a message at the given level with the redacted fields.
+func (l *RedactingLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: level, msg: msg, fields: fields, skip: 1})
+}
+
+// writeEntry writes the entry with the redacted fields to the underlying logger, keeping its caller.
+func (l *RedactingLogger) writeEntry(ctx context.Context, e entry) {
+	e.fields = l.redactor.Redact(e.fields...)
+	// The fields extracted from the context by the underlying logger are redacted too
+	if ctx != nil {
+		ctx = context.WithValue(ctx, redactorCtxKey, l.redactor)
+	}
+	// Skip this method too
+	e.skip++
+	writeEntry(ctx, l.logger, e)
+}
+
+// SetLevel updates the level of the underlying logger.
+func (l *RedactingLogger) SetLevel(level Level) error {
+	return l.logger.SetLevel(level)
+}
+
+// GetLevel returns the level of the underlying logger.
+func (l *RedactingLogger) GetLevel() Level {
+	return l.logger.GetLevel()
+}
+
+// With returns a new redacting logger with the additional fields redacted.
+func (l *RedactingLogger) With(fields ...Field) Logger {
+	return &RedactingLogger{
+		logger:   l.logger.With(l.redactor.Redact(fields...)...),
+		redactor: l.redactor,
+	}
+}
+
+// Named returns a new redacting logger with a named underlying logger.
+func (l *RedactingLogger) Named(name string) Logger {
+	return &RedactingLogger{
+		logger:   l.logger.Named(name),
+		redactor: l.redactor,
+	}
+}
+
+// Registry returns the registry of the named loggers of the underlying logger.
+func (l *RedactingLogger) Registry() *LevelRegistry {
+	return RegistryOf(l.logger)
+}
+
+// Sync flushes the underlying logger.
+func (l *RedactingLogger) Sync() error {
+	return l.logger.Sync()
+}
+
+// Close closes the underlying logger. See Close.
+func (l *RedactingLogger) Close() error {
+	return Close(l.logger)
+}
@@ -0,0 +1,247 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+func TestRedactor_IsSensitiveKey(t *testing.T) {
+	t.Parallel()
+
+	redactor := log.NewRedactor(log.DefaultRedactionOpts())
+
+	tests := []struct {
+		key       string
+		sensitive bool
+	}{
+		{key: "password", sensitive: true},
+		{key: "PASSWORD", sensitive: true},
+		{key: "header_authorization", sensitive: true},
+		{key: "user.password", sensitive: true},
+		{key: "x-api_key", sensitive: true},
+		{key: "X-Api-Key", sensitive: true},
+		{key: "header_x-api-key", sensitive: true},
+		{key: "username", sensitive: false},
+		{key: "tokenizer", sensitive: false},
+		{key: "password_hint_shown", sensitive: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.sensitive, redactor.IsSensitiveKey(tt.key))
+		})
+	}
+}
+
+func TestRedactor_RedactString(t *testing.T) {
+	t.Parallel()
+
+	redactor := log.NewRedactor(log.DefaultRedactionOpts())
+
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{name: "Email", input: "contact john.doe@example.com now", expected: "contact [REDACTED] now"},
+		{name: "CardNumber", input: "card 4111 1111 1111 1111 used", expected: "card [REDACTED] used"},
+		{name: "BearerToken", input: "Bearer eyJhbGciOi.abc-123", expected: "[REDACTED]"},
+		{name: "JSONKeyValue", input: `{"user":"john","password":"s3cr3t"}`, expected: `{"user":"john","password":"[REDACTED]"}`},
+		{name: "QueryKeyValue", input: "page=1&token=abc123&sort=asc", expected: "page=1&token=[REDACTED]&sort=asc"},
+		{name: "NoSensitiveData", input: "nothing to see here", expected: "nothing to see here"},
+		{name: "Timestamp", input: "1700000000000", expected: "1700000000000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tt.expected, redactor.RedactString(tt.input))
+		})
+	}
+}
+
+func TestRedactor_Redact(t *testing.T) {
+	t.Parallel()
+
+	redactor := log.NewRedactor(log.RedactionOpts{
+		Keys:     []string{"password"},
+		Patterns: []*regexp.Regexp{log.PatternEmail},
+		Mask:     "***",
+	})
+
+	fields := []log.Field{
+		log.String("password", "s3cr3t"),
+		log.Int("password", 1234),
+		log.String("email", "john@example.com"),
+		log.Int("count", 1),
+		log.Group("user", log.String("password", "s3cr3t"), log.String("name", "john")),
+	}
+
+	redacted := redactor.Redact(fields...)
+
+	require.Len(t, redacted, 5)
+	assert.Equal(t, log.String("password", "***"), redacted[0])
+	assert.Equal(t, log.String("password", "***"), redacted[1])
+	assert.Equal(t, log.String("email", "***"), redacted[2])
+	assert.Equal(t, log.Int("count", 1), redacted[3])
+	assert.Equal(t, []log.Field{log.String("password", "***"), log.String("name", "john")}, redacted[4].Fields())
+
+	// The original fields are not modified
+	assert.Equal(t, "s3cr3t", fields[0].String)
+}
+
+func TestRedactor_Redact_Errors(t *testing.T) {
+	t.Parallel()
+
+	redactor := log.NewRedactor(log.RedactionOpts{
+		Keys:     []string{"password"},
+		Patterns: []*regexp.Regexp{log.PatternEmail},
+		Mask:     "***",
+	})
+
+	err := fmt.Errorf("login of john@example.com failed: %w", errors.New("invalid password=s3cr3t"))
+	redacted := redactor.Redact(log.Err(err), log.NamedErr("password", err))
+
+	require.Len(t, redacted, 3)
+	assert.Equal(t, log.ErrorType, redacted[0].Type)
+	assert.EqualError(t, redacted[0].Interface.(error), "login of *** failed: invalid password=***")
+	assert.Equal(t, log.Any("error_causes", []string{"invalid password=***"}), redacted[1])
+	assert.Equal(t, log.String("password", "***"), redacted[2])
+}
+
+func TestRedactor_Redact_AnyValues(t *testing.T) {
+	t.Parallel()
+
+	redactor := log.NewRedactor(log.RedactionOpts{
+		Keys:     []string{"password", "token"},
+		Patterns: []*regexp.Regexp{log.PatternEmail},
+		Mask:     "***",
+	})
+
+	user := map[string]any{
+		"name":     "john",
+		"password": "s3cr3t",
+		"contacts": []any{"john@example.com", map[string]any{"token": "abc", "id": 1}},
+		"emails":   []string{"john@example.com"},
+		"headers":  map[string]string{"X-Token": "abc", "Accept": "*/*"},
+	}
+	redacted := redactor.Redact(log.Any("user", user), log.Any("emails", []string{"jane@example.com"}))
+
+	require.Len(t, redacted, 2)
+	assert.Equal(t, map[string]any{
+		"name":     "john",
+		"password": "***",
+		"contacts": []any{"***", map[string]any{"token": "***", "id": 1}},
+		"emails":   []string{"***"},
+		"headers":  map[string]string{"X-Token": "***", "Accept": "*/*"},
+	}, redacted[0].Interface)
+	assert.Equal(t, []string{"***"}, redacted[1].Interface)
+
+	// The original values are not modified
+	assert.Equal(t, "s3cr3t", user["password"])
+	assert.Equal(t, []string{"john@example.com"}, user["emails"])
+}
+
+func TestRedactingLogger(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelDebug)
+	logger := log.NewRedactingLogger(inMemoryLogger, log.NewRedactor(log.DefaultRedactionOpts()))
+
+	logger.Info(context.Background(), "user logged in",
+		log.String("password", "s3cr3t"),
+		log.String("user", "john"),
+	)
+
+	entries := inMemoryLogger.Entries()
+	require.Len(t, entries, 1)
+
+	password, _ := entries[0].GetField("password")
+	assert.Equal(t, log.DefaultRedactionMask, password.String)
+
+	user, _ := entries[0].GetField("user")
+	assert.Equal(t, "john", user.String)
+}
+
+func TestRedactingLogger_ContextFields(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelDebug)
+	logger := log.NewRedactingLogger(inMemoryLogger, log.NewRedactor(log.DefaultRedactionOpts()))
+
+	ctx := log.ContextWithFields(context.Background(),
+		log.String("token", "abc"),
+		log.String("user", "john@example.com"),
+		log.String("tenant", "acme"),
+	)
+	logger.Info(ctx, "request received")
+	inMemoryLogger.Info(ctx, "not redacted")
+
+	entries := inMemoryLogger.Entries()
+	require.Len(t, entries, 2)
+
+	token, _ := entries[0].GetField("token")
+	assert.Equal(t, log.DefaultRedactionMask, token.String)
+	user, _ := entries[0].GetField("user")
+	assert.Equal(t, log.DefaultRedactionMask, user.String)
+	tenant, _ := entries[0].GetField("tenant")
+	assert.Equal(t, "acme", tenant.String)
+
+	// Only the entries written through the redacting logger are redacted
+	token, _ = entries[1].GetField("token")
+	assert.Equal(t, "abc", token.String)
+}
+
+func TestRedactingLogger_Level(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelInfo)
+	logger := log.NewRedactingLogger(inMemoryLogger, log.NewRedactor(log.DefaultRedactionOpts()))
+
+	require.NoError(t, logger.SetLevel(log.LevelWarn))
+	assert.Equal(t, log.LevelWarn, logger.GetLevel())
+	assert.NoError(t, logger.Sync())
+}
+
+func TestNew_WithRedaction(t *testing.T) {
+	t.Parallel()
+
+	logger, err := log.New(
+		log.WithAdapter(log.AdapterNop),
+		log.WithRedaction(log.DefaultRedactionOpts()),
+	)
+	require.NoError(t, err)
+	assert.IsType(t, &log.RedactingLogger{}, logger)
+	assert.IsType(t, &log.RedactingLogger{}, logger.With(log.String("token", "abc")))
+}
+
+func TestRedactingLogger_Caller(t *testing.T) {
+	t.Parallel()
+
+	loggers, read := newConsoleFileLoggers(t)
+	ctx := context.Background()
+	for _, inner := range loggers {
+		logger := log.NewRedactingLogger(inner, log.NewRedactor(log.DefaultRedactionOpts()))
+		logger.Info(ctx, "user logged in", log.String("password", "secret"))
+		logger.Named("auth").Log(ctx, log.LevelWarn, "warn message")
+		assert.Panics(t, func() {
+			logger.Panic(ctx, "panic message")
+		})
+		require.NoError(t, logger.Sync())
+	}
+
+	contents := read()
+	assertCallers(t, contents, 3, "redact_test.go")
+	for _, content := range contents {
+		assert.NotContains(t, content, "secret")
+	}
+}
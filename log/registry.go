@@ -0,0 +1,135 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// ErrUnknownLogger is returned when no named logger is registered with the given name.
+var ErrUnknownLogger = errors.New("logger: unknown logger")
+
+// LevelRegistry keeps track of the named loggers created with Logger.Named, so that their
+// levels can be changed at runtime by name, for example from the log/handler.LevelHandler.
+//
+// The loggers created with the same name share the same level, so changing the level of
+// a name applies to all of them.
+type LevelRegistry struct {
+	mu      sync.RWMutex
+	loggers map[string]Logger
+}
+
+// NewLevelRegistry creates a new empty LevelRegistry.
+func NewLevelRegistry() *LevelRegistry {
+	return &LevelRegistry{
+		loggers: make(map[string]Logger),
+	}
+}
+
+// RegistryOf returns the registry of the named loggers created from the provided logger,
+// or nil if the logger does not keep track of its named loggers.
+func RegistryOf(logger Logger) *LevelRegistry {
+	if provider, ok := logger.(interface{ Registry() *LevelRegistry }); ok {
+		return provider.Registry()
+	}
+	return nil
+}
+
+// Names returns the sorted names of the registered loggers.
+func (r *LevelRegistry) Names() []string {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	names := make([]string, 0, len(r.loggers))
+	for name := range r.loggers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Levels returns the current level of each registered logger, by name.
+func (r *LevelRegistry) Levels() map[string]Level {
+	if r == nil {
+		return map[string]Level{}
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	levels := make(map[string]Level, len(r.loggers))
+	for name, logger := range r.loggers {
+		levels[name] = logger.GetLevel()
+	}
+	return levels
+}
+
+// GetLevel returns the level of the logger registered with the given name.
+func (r *LevelRegistry) GetLevel(name string) (Level, error) {
+	logger, err := r.lookup(name)
+	if err != nil {
+		return LevelInfo, err
+	}
+	return logger.GetLevel(), nil
+}
+
+// SetLevel updates the level of the logger registered with the given name.
+func (r *LevelRegistry) SetLevel(name string, level Level) error {
+	logger, err := r.lookup(name)
+	if err != nil {
+		return err
+	}
+	return logger.SetLevel(level)
+}
+
+// lookup returns the logger registered with the given name.
+func (r *LevelRegistry) lookup(name string) (Logger, error) {
+	if r == nil {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLogger, name)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	logger, ok := r.loggers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownLogger, name)
+	}
+	return logger, nil
+}
+
+// register creates a named logger with newLogger, which receives the logger previously
+// registered with the same name (or nil), so that the new logger can share its level.
+// Only the first logger created with each name is kept in the registry.
+func (r *LevelRegistry) register(name string, newLogger func(registered Logger) Logger) Logger {
+	if r == nil {
+		return newLogger(nil)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	registered := r.loggers[name]
+	logger := newLogger(registered)
+	if registered == nil {
+		r.loggers[name] = logger
+	}
+	return logger
+}
+
+// joinLoggerName appends the name of a child logger to the name of its parent, separated by a dot.
+func joinLoggerName(parent, name string) string {
+	switch {
+	case parent == "":
+		return name
+	case name == "":
+		return parent
+	default:
+		return parent + "." + name
+	}
+}
@@ -0,0 +1,97 @@
+package log_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+func TestLevelRegistry(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewInMemory(log.LevelInfo)
+	dbLogger := logger.Named("db")
+	httpLogger := logger.Named("http").Named("client")
+
+	registry := log.RegistryOf(logger)
+	require.NotNil(t, registry)
+	assert.Equal(t, []string{"db", "http", "http.client"}, registry.Names())
+
+	require.NoError(t, registry.SetLevel("db", log.LevelDebug))
+
+	level, err := registry.GetLevel("db")
+	require.NoError(t, err)
+	assert.Equal(t, log.LevelDebug, level)
+	assert.Equal(t, log.LevelDebug, dbLogger.GetLevel())
+	assert.Equal(t, log.LevelInfo, httpLogger.GetLevel())
+	assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	assert.Equal(t, map[string]log.Level{
+		"db":          log.LevelDebug,
+		"http":        log.LevelInfo,
+		"http.client": log.LevelInfo,
+	}, registry.Levels())
+}
+
+func TestLevelRegistry_UnknownLogger(t *testing.T) {
+	t.Parallel()
+
+	registry := log.NewLevelRegistry()
+
+	_, err := registry.GetLevel("db")
+	assert.ErrorIs(t, err, log.ErrUnknownLogger)
+	assert.ErrorIs(t, registry.SetLevel("db", log.LevelDebug), log.ErrUnknownLogger)
+}
+
+func TestLevelRegistry_NilRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry := log.RegistryOf(log.NewNop())
+
+	assert.Nil(t, registry)
+	assert.Empty(t, registry.Names())
+	assert.ErrorIs(t, registry.SetLevel("db", log.LevelDebug), log.ErrUnknownLogger)
+}
+
+func TestNamed_SharesLevelByName(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewInMemory(log.LevelInfo)
+	first := logger.Named("db")
+	second := logger.With(log.String("key", "value")).Named("db")
+
+	require.NoError(t, first.SetLevel(log.LevelDebug))
+
+	assert.Equal(t, log.LevelDebug, second.GetLevel())
+}
+
+func TestNamed_WrappedLoggers(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelInfo)
+	logger := log.NewSampler(log.NewRedactingLogger(inMemoryLogger, log.NewRedactor(log.DefaultRedactionOpts())), log.SamplingOpts{First: 10})
+
+	dbLogger := logger.Named("db")
+	require.NoError(t, log.RegistryOf(logger).SetLevel("db", log.LevelDebug))
+
+	assert.Equal(t, log.LevelDebug, dbLogger.GetLevel())
+	assert.Equal(t, log.LevelInfo, logger.GetLevel())
+}
+
+func TestTeeLogger_Named(t *testing.T) {
+	t.Parallel()
+
+	first := log.NewInMemory(log.LevelInfo)
+	second := log.NewInMemory(log.LevelWarn)
+	tee := log.NewTee(first, second)
+
+	dbLogger := tee.Named("db")
+	require.NoError(t, log.RegistryOf(tee).SetLevel("db", log.LevelDebug))
+
+	assert.Equal(t, log.LevelDebug, log.RegistryOf(first).Levels()["db"])
+	assert.Equal(t, log.LevelDebug, log.RegistryOf(second).Levels()["db"])
+	assert.Equal(t, log.LevelDebug, dbLogger.GetLevel())
+	assert.Equal(t, log.LevelInfo, tee.GetLevel())
+}
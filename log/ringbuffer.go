@@ -0,0 +1,59 @@
+package log
+
+// DefaultRingBufferCapacity is the number of entries kept by a RingBufferLogger when no capacity is provided.
+const DefaultRingBufferCapacity = 1000
+
+// RingBufferLogger keeps the most recent log entries in memory, in a ring buffer with a fixed capacity.
+// Once the buffer is full, each new entry replaces the oldest one, so the memory used by the logger is bounded.
+//
+// Unlike the InMemoryLogger, it is meant to be used in production, usually combined with another logger
+// using NewTee, to inspect the recent entries of a running instance (see the log/handler.LogsHandler).
+// Its Fatal method exits the process after logging the entry.
+type RingBufferLogger struct {
+	*InMemoryLogger
+}
+
+// NewRingBuffer creates a new RingBufferLogger that keeps the last capacity entries with the provided level.
+// If the capacity is not positive, DefaultRingBufferCapacity is used.
+// Usage:
+//
+//	ring := log.NewRingBuffer(log.LevelDebug, 500)
+//	zapLogger, _ := log.New(log.WithAdapter(log.AdapterZap))
+//	logger := log.NewTee(zapLogger, ring)
+//	http.Handle("/debug/logs", handler.LogsHandler(ring))
+func NewRingBuffer(level Level, capacity int) *RingBufferLogger {
+	if capacity <= 0 {
+		capacity = DefaultRingBufferCapacity
+	}
+
+	return &RingBufferLogger{
+		InMemoryLogger: &InMemoryLogger{
+			level:       newLevelVar(level),
+			store:       newInMemoryStore(capacity),
+			registry:    NewLevelRegistry(),
+			exitOnFatal: true,
+		},
+	}
+}
+
+// Capacity returns the maximum number of entries kept by the logger.
+func (l *RingBufferLogger) Capacity() int {
+	return l.store.capacity
+}
+
+// Last returns the last n entries matching all the matchers, from the oldest to the most recent one.
+// If n is not positive, all the matching entries are returned.
+func (l *RingBufferLogger) Last(n int, matchers ...EntryMatcher) []InMemoryLogEntry {
+	entries := l.Filter(matchers...)
+	if n > 0 && len(entries) > n {
+		entries = entries[len(entries)-n:]
+	}
+	return entries
+}
+
+// Subscribe returns a channel that receives the entries logged from now on, and a function to
+// cancel the subscription, which closes the channel. The channel holds up to buffer entries;
+// when it is full, new entries are dropped for that subscriber, so slow subscribers never block the loggers.
+func (l *RingBufferLogger) Subscribe(buffer int) (<-chan InMemoryLogEntry, func()) {
+	return l.store.subscribe(buffer)
+}
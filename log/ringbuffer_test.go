@@ -0,0 +1,111 @@
+package log_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// messages returns the messages of the entries.
+func messages(entries []log.InMemoryLogEntry) []string {
+	msgs := make([]string, len(entries))
+	for i, entry := range entries {
+		msgs[i] = entry.Message
+	}
+	return msgs
+}
+
+func TestNewRingBuffer(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, 10, log.NewRingBuffer(log.LevelInfo, 10).Capacity())
+	assert.Equal(t, log.DefaultRingBufferCapacity, log.NewRingBuffer(log.LevelInfo, 0).Capacity())
+}
+
+func TestRingBufferLogger_KeepsMostRecentEntries(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewRingBuffer(log.LevelDebug, 3)
+	child := logger.With(log.String("key", "value"))
+	for i := range 5 {
+		child.Info(context.Background(), fmt.Sprintf("message %d", i))
+	}
+
+	assert.Equal(t, []string{"message 2", "message 3", "message 4"}, messages(logger.Entries()))
+
+	logger.Reset()
+	assert.Empty(t, logger.Entries())
+
+	logger.Info(context.Background(), "message 5")
+	assert.Equal(t, []string{"message 5"}, messages(logger.Entries()))
+}
+
+func TestRingBufferLogger_Last(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewRingBuffer(log.LevelDebug, 10)
+	ctx := context.Background()
+	logger.Debug(ctx, "debug 1")
+	logger.Error(ctx, "error 1")
+	logger.Warn(ctx, "warn 1")
+	logger.Error(ctx, "error 2")
+
+	assert.Equal(t, []string{"warn 1", "error 2"}, messages(logger.Last(2)))
+	assert.Equal(t, []string{"error 1", "warn 1", "error 2"}, messages(logger.Last(0, log.MatchMinLevel(log.LevelWarn))))
+	assert.Equal(t, []string{"error 2"}, messages(logger.Last(1, log.MatchLevel(log.LevelError))))
+}
+
+func TestRingBufferLogger_Subscribe(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewRingBuffer(log.LevelInfo, 10)
+	logger.Info(context.Background(), "before subscribing")
+
+	entries, cancel := logger.Subscribe(1)
+	logger.Named("http").Info(context.Background(), "first")
+	// The buffer is full, so the entry is dropped instead of blocking the logger.
+	logger.Info(context.Background(), "second")
+
+	select {
+	case entry := <-entries:
+		assert.Equal(t, "first", entry.Message)
+		assert.Equal(t, "http", entry.Logger)
+	case <-time.After(time.Second):
+		require.Fail(t, "no entry received")
+	}
+
+	cancel()
+	cancel()
+	_, ok := <-entries
+	assert.False(t, ok)
+
+	logger.Info(context.Background(), "after cancelling")
+	assert.Len(t, logger.Entries(), 4)
+}
+
+func TestRingBufferLogger_ConcurrentLogging(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewRingBuffer(log.LevelInfo, 100)
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range 100 {
+				logger.Info(context.Background(), "message")
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, logger.Entries(), 100)
+}
@@ -0,0 +1,259 @@
+package log
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"sync/atomic"
+	"time"
+
+	"github.com/brpaz/lib-go/timeutil"
+)
+
+// samplerCounters is the number of counters per level. Messages are mapped to the
+// counters by their hash, which keeps the memory used by the sampler bounded.
+const samplerCounters = 4096
+
+// Sampling counts used when neither First nor Thereafter is set, like in the zap production config.
+const (
+	defaultSamplingFirst      = 100
+	defaultSamplingThereafter = 100
+)
+
+var ErrInvalidSamplingOpts = errors.New("invalid log sampling options")
+
+// SamplingOpts specifies the options to sample log entries.
+//
+// Within each Tick, the first First entries with a given level and message are logged,
+// and after that only every Thereafter-th entry is logged. The remaining entries are dropped.
+// When both First and Thereafter are zero, which would drop every entry, they default to 100.
+type SamplingOpts struct {
+	// Tick is the interval after which the counters are reset. Defaults to one second.
+	Tick time.Duration
+	// First is the number of entries with the same level and message logged in each tick.
+	First int
+	// Thereafter defines that every Thereafter-th entry is logged after the first ones.
+	// If zero, all the entries after the first ones are dropped.
+	Thereafter int
+	// KeepErrors disables the sampling of entries with the Error level.
+	// Entries with the Panic and Fatal levels are never sampled.
+	KeepErrors bool
+	// Clock is used to get the current time. Defaults to the real clock.
+	Clock timeutil.Clock
+}
+
+// Validate checks if the sampling options are valid.
+func (o SamplingOpts) Validate() error {
+	if o.Tick < 0 || o.First < 0 || o.Thereafter < 0 {
+		return ErrInvalidSamplingOpts
+	}
+	return nil
+}
+
+// SamplingStats holds the number of entries logged and dropped by a SampledLogger.
+type SamplingStats struct {
+	Logged  uint64
+	Dropped uint64
+}
+
+// SampledLogger is a logger that samples the entries before writing them to the underlying logger.
+// It can wrap any Logger implementation.
+type SampledLogger struct {
+	logger Logger
+	state  *samplerState
+}
+
+// samplerState is shared by a SampledLogger and the child loggers created with With.
+type samplerState struct {
+	opts     SamplingOpts
+	counters [LevelError - LevelTrace + 1][samplerCounters]samplerCounter
+	logged   atomic.Uint64
+	dropped  atomic.Uint64
+}
+
+// samplerCounter counts the entries logged with the same key during a tick.
+type samplerCounter struct {
+	resetAt atomic.Int64
+	count   atomic.Uint64
+}
+
+// NewSampler creates a new logger that samples the entries written to the provided logger.
+// Usage:
+//
+//	logger := log.NewSampler(zapLogger, log.SamplingOpts{
+//		First:      10,
+//		Thereafter: 100,
+//		KeepErrors: true,
+//	})
+func NewSampler(logger Logger, opts SamplingOpts) *SampledLogger {
+	if opts.Tick <= 0 {
+		opts.Tick = time.Second
+	}
+	if opts.Clock == nil {
+		opts.Clock = timeutil.NewRealClock()
+	}
+	if opts.First == 0 && opts.Thereafter == 0 {
+		opts.First, opts.Thereafter = defaultSamplingFirst, defaultSamplingThereafter
+	}
+
+	return &SampledLogger{
+		logger: logger,
+		state:  &samplerState{opts: opts},
+	}
+}
+
+// WithSampling enables the sampling of log entries with the provided options.
+func WithSampling(opts SamplingOpts) LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.Sampling = &opts
+	}
+}
+
+// Stats returns the number of entries logged and dropped by the sampler.
+// The counters are shared with the child loggers created with With.
+func (l *SampledLogger) Stats() SamplingStats {
+	return SamplingStats{
+		Logged:  l.state.logged.Load(),
+		Dropped: l.state.dropped.Load(),
+	}
+}
+
+// Info logs an informational message, if sampled.
+func (l *SampledLogger) Info(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelInfo, msg: msg, fields: fields, skip: 1})
+}
+
+// Warn logs a warning message, if sampled.
+func (l *SampledLogger) Warn(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelWarn, msg: msg, fields: fields, skip: 1})
+}
+
+// Error logs an error message, if sampled.
+func (l *SampledLogger) Error(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelError, msg: msg, fields: fields, skip: 1})
+}
+
+// Debug logs a debug message, if sampled.
+func (l *SampledLogger) Debug(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelDebug, msg: msg, fields: fields, skip: 1})
+}
+
+// Trace logs a trace message, if sampled.
+func (l *SampledLogger) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelTrace, msg: msg, fields: fields, skip: 1})
+}
+
+// Panic logs a panic message and then panics. Panic messages are never dropped.
+func (l *SampledLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelPanic, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Fatal logs a fatal message and then exits. Fatal messages are never dropped.
+func (l *SampledLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelFatal, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Log logs a message at the given level, if sampled.
+func (l *SampledLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: level, msg: msg, fields: fields, skip: 1})
+}
+
+// writeEntry writes the entry to the underlying logger, if sampled, keeping its caller.
+// Terminating entries are always written, so that the logger panics or exits.
+func (l *SampledLogger) writeEntry(ctx context.Context, e entry) {
+	if !l.sample(e.level, e.msg) && !e.terminate {
+		return
+	}
+
+	// Skip this method too
+	e.skip++
+	writeEntry(ctx, l.logger, e)
+}
+
+// SetLevel updates the level of the underlying logger.
+func (l *SampledLogger) SetLevel(level Level) error {
+	return l.logger.SetLevel(level)
+}
+
+// GetLevel returns the level of the underlying logger.
+func (l *SampledLogger) GetLevel() Level {
+	return l.logger.GetLevel()
+}
+
+// With returns a new sampled logger with additional fields, sharing the counters with the parent logger.
+func (l *SampledLogger) With(fields ...Field) Logger {
+	return &SampledLogger{
+		logger: l.logger.With(fields...),
+		state:  l.state,
+	}
+}
+
+// Named returns a new sampled logger with a named underlying logger, sharing the counters with the parent logger.
+func (l *SampledLogger) Named(name string) Logger {
+	return &SampledLogger{
+		logger: l.logger.Named(name),
+		state:  l.state,
+	}
+}
+
+// Registry returns the registry of the named loggers of the underlying logger.
+func (l *SampledLogger) Registry() *LevelRegistry {
+	return RegistryOf(l.logger)
+}
+
+// Sync flushes the underlying logger.
+func (l *SampledLogger) Sync() error {
+	return l.logger.Sync()
+}
+
+// Close closes the underlying logger. See Close.
+func (l *SampledLogger) Close() error {
+	return Close(l.logger)
+}
+
+// sample reports whether an entry with the given level and message should be logged.
+// Entries below the logger level are not counted, since they would be discarded anyway.
+func (l *SampledLogger) sample(lvl Level, msg string) bool {
+	if lvl < l.logger.GetLevel() {
+		return false
+	}
+
+	s := l.state
+	if (lvl == LevelError && s.opts.KeepErrors) || lvl < LevelTrace || lvl > LevelError {
+		s.logged.Add(1)
+		return true
+	}
+
+	n := s.counters[lvl-LevelTrace][hashMessage(msg)%samplerCounters].incCheckReset(s.opts.Clock.Now(), s.opts.Tick)
+	first := uint64(s.opts.First)
+	if n <= first || (s.opts.Thereafter > 0 && (n-first)%uint64(s.opts.Thereafter) == 0) {
+		s.logged.Add(1)
+		return true
+	}
+
+	s.dropped.Add(1)
+	return false
+}
+
+// incCheckReset increments the counter, resetting it first if the tick has elapsed.
+func (c *samplerCounter) incCheckReset(t time.Time, tick time.Duration) uint64 {
+	now := t.UnixNano()
+	resetAt := c.resetAt.Load()
+	if resetAt > now {
+		return c.count.Add(1)
+	}
+
+	c.count.Store(1)
+	if !c.resetAt.CompareAndSwap(resetAt, now+tick.Nanoseconds()) {
+		// Another goroutine already reset the counter.
+		return c.count.Add(1)
+	}
+	return 1
+}
+
+// hashMessage computes the FNV-1a hash of the message.
+func hashMessage(msg string) uint32 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(msg))
+	return h.Sum32()
+}
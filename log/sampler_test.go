@@ -0,0 +1,252 @@
+package log_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// manualClock is a clock whose time only changes when advanced.
+type manualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *manualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *manualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func TestSamplingOpts_Validate(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, log.SamplingOpts{First: 10, Thereafter: 100}.Validate())
+	assert.ErrorIs(t, log.SamplingOpts{First: -1}.Validate(), log.ErrInvalidSamplingOpts)
+	assert.ErrorIs(t, log.SamplingOpts{Thereafter: -1}.Validate(), log.ErrInvalidSamplingOpts)
+	assert.ErrorIs(t, log.SamplingOpts{Tick: -time.Second}.Validate(), log.ErrInvalidSamplingOpts)
+}
+
+func TestSampledLogger_FirstAndThereafter(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelDebug)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{
+		First:      2,
+		Thereafter: 3,
+		Clock:      &manualClock{now: time.Now()},
+	})
+
+	for range 10 {
+		logger.Info(context.Background(), "hot path")
+	}
+
+	// Entries 1 and 2 are logged, then every third entry: 5 and 8.
+	assert.Len(t, inMemoryLogger.Entries(), 4)
+	assert.Equal(t, log.SamplingStats{Logged: 4, Dropped: 6}, logger.Stats())
+}
+
+func TestSampledLogger_Defaults(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelInfo)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{Clock: &manualClock{now: time.Now()}})
+
+	for range 200 {
+		logger.Info(context.Background(), "hot path")
+	}
+
+	// Without First and Thereafter, the first 100 entries and then every 100th entry are logged
+	assert.Len(t, inMemoryLogger.Entries(), 101)
+	assert.Equal(t, log.SamplingStats{Logged: 101, Dropped: 99}, logger.Stats())
+}
+
+func TestSampledLogger_KeysByLevelAndMessage(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelDebug)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{
+		First: 1,
+		Clock: &manualClock{now: time.Now()},
+	})
+
+	ctx := context.Background()
+	logger.Info(ctx, "first message")
+	logger.Info(ctx, "first message")
+	logger.Info(ctx, "second message")
+	logger.Warn(ctx, "first message")
+
+	assert.Len(t, inMemoryLogger.Entries(), 3)
+	assert.Equal(t, uint64(1), logger.Stats().Dropped)
+}
+
+func TestSampledLogger_ResetsAfterTick(t *testing.T) {
+	t.Parallel()
+
+	clock := &manualClock{now: time.Now()}
+	inMemoryLogger := log.NewInMemory(log.LevelDebug)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{
+		Tick:  time.Second,
+		First: 1,
+		Clock: clock,
+	})
+
+	ctx := context.Background()
+	logger.Info(ctx, "message")
+	logger.Info(ctx, "message")
+
+	clock.Advance(time.Second)
+	logger.Info(ctx, "message")
+
+	assert.Len(t, inMemoryLogger.Entries(), 2)
+	assert.Equal(t, log.SamplingStats{Logged: 2, Dropped: 1}, logger.Stats())
+}
+
+func TestSampledLogger_KeepErrors(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelDebug)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{
+		First:      1,
+		KeepErrors: true,
+		Clock:      &manualClock{now: time.Now()},
+	})
+
+	for range 5 {
+		logger.Error(context.Background(), "failure")
+	}
+
+	assert.Len(t, inMemoryLogger.Entries(), 5)
+	assert.Equal(t, uint64(0), logger.Stats().Dropped)
+}
+
+func TestSampledLogger_NeverSamplesFatal(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelTrace)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{First: 1, Clock: &manualClock{now: time.Now()}})
+
+	for range 3 {
+		logger.Trace(context.Background(), "trace message")
+		logger.Fatal(context.Background(), "fatal message")
+	}
+
+	// The in-memory logger does not exit on Fatal, so all the fatal entries are recorded.
+	assert.Len(t, inMemoryLogger.Entries(), 4)
+	assert.Equal(t, log.SamplingStats{Logged: 4, Dropped: 2}, logger.Stats())
+}
+
+func TestSampledLogger_IgnoresEntriesBelowLevel(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewSampler(log.NewInMemory(log.LevelWarn), log.SamplingOpts{First: 1})
+
+	for range 5 {
+		logger.Debug(context.Background(), "debug message")
+	}
+
+	assert.Equal(t, log.SamplingStats{}, logger.Stats())
+}
+
+func TestSampledLogger_WithSharesCounters(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewSampler(log.NewNop(), log.SamplingOpts{
+		First: 1,
+		Clock: &manualClock{now: time.Now()},
+	})
+	child := logger.With(log.String("key", "value"))
+
+	logger.Info(context.Background(), "message")
+	child.Info(context.Background(), "message")
+
+	assert.Equal(t, log.SamplingStats{Logged: 1, Dropped: 1}, logger.Stats())
+}
+
+func TestSampledLogger_Level(t *testing.T) {
+	t.Parallel()
+
+	inMemoryLogger := log.NewInMemory(log.LevelInfo)
+	logger := log.NewSampler(inMemoryLogger, log.SamplingOpts{})
+
+	require.NoError(t, logger.SetLevel(log.LevelError))
+	assert.Equal(t, log.LevelError, logger.GetLevel())
+	assert.Equal(t, log.LevelError, inMemoryLogger.GetLevel())
+	assert.NoError(t, logger.Sync())
+}
+
+func TestNew_WithSampling(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WrapsLogger", func(t *testing.T) {
+		t.Parallel()
+		logger, err := log.New(
+			log.WithAdapter(log.AdapterNop),
+			log.WithSampling(log.SamplingOpts{First: 10, Thereafter: 100}),
+		)
+		require.NoError(t, err)
+		assert.IsType(t, &log.SampledLogger{}, logger)
+	})
+
+	t.Run("ZapAdapter", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "app.log")
+		logger, err := log.New(
+			log.WithRotatingFile(log.FileOutputOpts{Path: path}),
+			log.WithSampling(log.SamplingOpts{First: 1, KeepErrors: true}),
+		)
+		require.NoError(t, err)
+
+		ctx := context.Background()
+		for range 500 {
+			logger.Error(ctx, "upstream failed")
+			logger.Info(ctx, "request received")
+		}
+		require.NoError(t, log.Close(logger))
+
+		// The entries are only sampled by the SampledLogger, and not by zap too
+		content, err := os.ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, 501, strings.Count(string(content), "\n"))
+		assert.Equal(t, log.SamplingStats{Logged: 501, Dropped: 499}, logger.(*log.SampledLogger).Stats())
+	})
+
+	t.Run("InvalidOpts", func(t *testing.T) {
+		t.Parallel()
+		_, err := log.New(log.WithSampling(log.SamplingOpts{First: -1}))
+		assert.ErrorIs(t, err, log.ErrInvalidSamplingOpts)
+	})
+}
+
+func TestSampledLogger_Caller(t *testing.T) {
+	t.Parallel()
+
+	loggers, read := newConsoleFileLoggers(t)
+	ctx := context.Background()
+	for _, inner := range loggers {
+		logger := log.NewSampler(inner, log.SamplingOpts{First: 10, Clock: &manualClock{now: time.Now()}})
+		logger.Info(ctx, "info message")
+		logger.With(log.String("key", "value")).Log(ctx, log.LevelWarn, "warn message")
+		assert.Panics(t, func() {
+			logger.Panic(ctx, "panic message")
+		})
+		require.NoError(t, logger.Sync())
+	}
+
+	assertCallers(t, read(), 3, "sampler_test.go")
+}
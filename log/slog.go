@@ -0,0 +1,426 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+)
+
+// slog levels used for the levels that the slog package does not define.
+const (
+	slogLevelTrace = slog.LevelDebug - 4
+	slogLevelPanic = slog.LevelError + 4
+	slogLevelFatal = slog.LevelError + 8
+)
+
+// Keys of the attributes added by the slog adapter.
+const (
+	// slogLoggerNameKey is the key of the attribute with the name of named loggers.
+	slogLoggerNameKey = "logger"
+	// slogStacktraceKey is the key of the attribute with the stack trace, like in the zap adapter.
+	slogStacktraceKey = stacktraceKey
+)
+
+// SlogAdapter is a wrapper around slog.Logger from the standard library that implements the Logger interface.
+type SlogAdapter struct {
+	SlogL      *slog.Logger
+	LevelVar   *slog.LevelVar
+	name       string
+	registry   *LevelRegistry
+	extractors []ContextExtractor
+	// stacktrace is the minimum level of the entries that include a stack trace, or nil if disabled.
+	stacktrace slog.Leveler
+	// closers close the writers of the outputs, shared by the child loggers.
+	closers []io.Closer
+}
+
+// SlogLoggerOpts specifies options to configure the slog logger instance.
+type SlogLoggerOpts struct {
+	Level   Level
+	Profile string
+	// Format is the format of the entries. When empty, it defaults to console in the development
+	// profile and json in the production profile.
+	Format  string
+	Outputs []Output
+	// ContextExtractors extract the fields added to every entry from the context.
+	// Defaults to DefaultContextExtractors when nil, while an empty slice disables the extraction.
+	ContextExtractors []ContextExtractor
+	// StacktraceLevel is the minimum level of the entries that include a stack trace.
+	// When nil, it defaults to Warn in the development profile and Error in the production profile.
+	StacktraceLevel *Level
+	// DisableStacktrace disables the stack traces, for every level.
+	DisableStacktrace bool
+}
+
+// Validate checks if the provided slog logger options are valid.
+func (o SlogLoggerOpts) Validate() error {
+	if !slices.Contains(allowedProfiles, o.Profile) {
+		return fmt.Errorf("%w: %s", ErrInvalidProfile, o.Profile)
+	}
+
+	if !slices.Contains(allowedFormats, o.Format) {
+		return fmt.Errorf("%w: %s", ErrInvalidFormat, o.Format)
+	}
+
+	return validateOutputs(o.Outputs)
+}
+
+// defaultSlogLoggerOpts defines the default options for the slog logger.
+var defaultSlogLoggerOpts = SlogLoggerOpts{
+	Level:   LevelInfo,
+	Profile: ProfileProduction,
+	Format:  FormatJSON,
+}
+
+// DefaultSlogLoggerOpts returns a copy of the default slog logger options.
+func DefaultSlogLoggerOpts() SlogLoggerOpts {
+	return defaultSlogLoggerOpts
+}
+
+// NewSlog creates a new logger backed by the standard library log/slog package.
+func NewSlog(opts SlogLoggerOpts) (*SlogAdapter, error) {
+	if opts.Format == "" {
+		opts.Format = profileFormat(opts.Profile)
+	}
+	if err := opts.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to create slog logger: %w", err)
+	}
+
+	writers, closers, err := newOutputWriters(opts.Outputs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create slog logger: %w", err)
+	}
+	writer := io.MultiWriter(writers...)
+
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(toSlogLevel(opts.Level))
+
+	// The level is checked by the outermost handler, so that named loggers can replace it.
+	handlerOpts := &slog.HandlerOptions{
+		Level:       slogLevelTrace,
+		AddSource:   opts.Profile == ProfileDevelopment,
+		ReplaceAttr: replaceSlogLevelName,
+	}
+
+	var handler slog.Handler
+	switch opts.Format {
+	case FormatConsole:
+		handler = newSlogConsoleHandler(writer, handlerOpts.AddSource, colorEnabled(writers))
+	case FormatLogFmt:
+		handler = slog.NewTextHandler(writer, handlerOpts)
+	default:
+		handler = slog.NewJSONHandler(writer, handlerOpts)
+	}
+
+	var stacktrace slog.Leveler
+	if level, ok := stacktraceLevel(opts.Profile, opts.StacktraceLevel, opts.DisableStacktrace); ok {
+		stacktrace = toSlogLevel(level)
+	}
+
+	return &SlogAdapter{
+		SlogL:      slog.New(newSlogLevelHandler(handler, levelVar)),
+		LevelVar:   levelVar,
+		registry:   NewLevelRegistry(),
+		extractors: opts.ContextExtractors,
+		stacktrace: stacktrace,
+		closers:    closers,
+	}, nil
+}
+
+// log builds a slog record and sends it to the underlying handler.
+func (l *SlogAdapter) log(ctx context.Context, lvl slog.Level, msg string, fields ...Field) {
+	// Skip log and the public level method
+	l.write(ctx, time.Time{}, 0, 2, lvl, msg, fields)
+}
+
+// writeEntry writes an entry of a logger that wraps this logger.
+func (l *SlogAdapter) writeEntry(ctx context.Context, e entry) {
+	l.write(ctx, e.time, e.pc, e.skip+1, toSlogLevel(e.level), e.msg, e.fields)
+	if e.terminate {
+		terminate(l, e.level, e.msg)
+	}
+}
+
+// write builds a slog record and sends it to the underlying handler. The time of the record is t,
+// or the current time if t is zero, and its source is pc, or the code that logged the entry if pc
+// is zero, found by skipping the skip frames between that code and write.
+func (l *SlogAdapter) write(ctx context.Context, t time.Time, pc uintptr, skip int, lvl slog.Level, msg string, fields []Field) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	if !l.SlogL.Enabled(ctx, lvl) {
+		return
+	}
+	if t.IsZero() {
+		t = time.Now()
+	}
+
+	// Skip runtime.Callers, write and the given frames, so that the
+	// source points to the code that called the logger.
+	withStacktrace := l.stacktrace != nil && lvl >= l.stacktrace.Level() && !hasStacktraceField(fields)
+	depth := 1
+	if withStacktrace {
+		depth = stacktraceMaxDepth
+	}
+	pcs := make([]uintptr, depth)
+	n := runtime.Callers(skip+2, pcs)
+
+	if pc == 0 {
+		pc = pcs[0]
+	}
+	record := slog.NewRecord(t, lvl, msg, pc)
+	if l.name != "" {
+		record.AddAttrs(slog.String(slogLoggerNameKey, l.name))
+	}
+	if withStacktrace {
+		record.AddAttrs(slog.String(slogStacktraceKey, formatStacktrace(pcs[:n])))
+	}
+	record.AddAttrs(toSlogAttrs(append(fields, extractContextFields(ctx, l.extractors)...))...)
+
+	_ = l.SlogL.Handler().Handle(ctx, record)
+}
+
+// Info logs a message at the info level.
+func (l *SlogAdapter) Info(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slog.LevelInfo, msg, fields...)
+}
+
+// Warn logs a message at the warning level.
+func (l *SlogAdapter) Warn(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slog.LevelWarn, msg, fields...)
+}
+
+// Error logs a message at the error level.
+func (l *SlogAdapter) Error(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slog.LevelError, msg, fields...)
+}
+
+// Debug logs a message at the debug level.
+func (l *SlogAdapter) Debug(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slog.LevelDebug, msg, fields...)
+}
+
+// Trace logs a message at the trace level.
+func (l *SlogAdapter) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slogLevelTrace, msg, fields...)
+}
+
+// Panic logs a message at the panic level and then panics.
+func (l *SlogAdapter) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slogLevelPanic, msg, fields...)
+	panic(msg)
+}
+
+// Fatal logs a message at the fatal level and then calls os.Exit(1).
+func (l *SlogAdapter) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, slogLevelFatal, msg, fields...)
+	os.Exit(1)
+}
+
+// Log logs a message at the given level, without panicking or exiting.
+func (l *SlogAdapter) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.log(ctx, toSlogLevel(level), msg, fields...)
+}
+
+// SetLevel updates the logging level dynamically.
+func (l *SlogAdapter) SetLevel(level Level) error {
+	l.LevelVar.Set(toSlogLevel(level))
+	return nil
+}
+
+// GetLevel retrieves the current logging level.
+func (l *SlogAdapter) GetLevel() Level {
+	return fromSlogLevel(l.LevelVar.Level())
+}
+
+// With creates a new logger instance with additional fields.
+func (l *SlogAdapter) With(fields ...Field) Logger {
+	return &SlogAdapter{
+		SlogL:      slog.New(l.SlogL.Handler().WithAttrs(toSlogAttrs(fields))),
+		LevelVar:   l.LevelVar,
+		name:       l.name,
+		registry:   l.registry,
+		extractors: l.extractors,
+		stacktrace: l.stacktrace,
+		closers:    l.closers,
+	}
+}
+
+// Named creates a new logger instance with the given name appended to the logger name,
+// and a level of its own, starting at the current level of the parent logger.
+func (l *SlogAdapter) Named(name string) Logger {
+	fullName := joinLoggerName(l.name, name)
+	return l.registry.register(fullName, func(registered Logger) Logger {
+		levelVar := &slog.LevelVar{}
+		levelVar.Set(l.LevelVar.Level())
+		if named, ok := registered.(*SlogAdapter); ok {
+			levelVar = named.LevelVar
+		}
+
+		return &SlogAdapter{
+			SlogL:      slog.New(newSlogLevelHandler(l.SlogL.Handler(), levelVar)),
+			LevelVar:   levelVar,
+			name:       fullName,
+			registry:   l.registry,
+			extractors: l.extractors,
+			stacktrace: l.stacktrace,
+			closers:    l.closers,
+		}
+	})
+}
+
+// Registry returns the registry of the named loggers created from this logger.
+func (l *SlogAdapter) Registry() *LevelRegistry {
+	return l.registry
+}
+
+// Sync is a no-op, since slog handlers write their output synchronously.
+func (l *SlogAdapter) Sync() error {
+	return nil
+}
+
+// Close closes the files of the file outputs, of the whole logger tree,
+// including the loggers created with With and Named.
+func (l *SlogAdapter) Close() error {
+	return closeOutputs(l.closers)
+}
+
+// toSlogAttrs converts log fields to slog attributes, adding the fields that describe the errors.
+func toSlogAttrs(fields []Field) []slog.Attr {
+	fields = expandErrorFields(fields)
+	attrs := make([]slog.Attr, 0, len(fields))
+	for _, f := range fields {
+		if f.Type == ErrorType && f.Interface == nil {
+			continue
+		}
+		attrs = append(attrs, toSlogAttr(f))
+	}
+	return attrs
+}
+
+// toSlogAttr converts a single log field to a slog attribute.
+func toSlogAttr(f Field) slog.Attr {
+	switch f.Type {
+	case StringType:
+		return slog.String(f.Key, f.String)
+	case BoolType:
+		return slog.Bool(f.Key, f.Integer == 1)
+	case Int64Type:
+		return slog.Int64(f.Key, f.Integer)
+	case Uint64Type:
+		return slog.Uint64(f.Key, uint64(f.Integer))
+	case Float64Type, Float32Type:
+		return slog.Float64(f.Key, f.Float)
+	case DurationType:
+		return slog.Duration(f.Key, time.Duration(f.Integer))
+	case TimeType:
+		t, _ := f.Interface.(time.Time)
+		return slog.Time(f.Key, t)
+	case StringerType:
+		return slog.String(f.Key, stringerValue(f.Interface))
+	case GroupType, ObjectType:
+		return slog.Attr{Key: f.Key, Value: slog.GroupValue(toSlogAttrs(f.Fields())...)}
+	default:
+		return slog.Any(f.Key, f.Interface)
+	}
+}
+
+// slogLevelHandler is a slog.Handler that filters the records using its own level,
+// before passing them to the wrapped handler.
+type slogLevelHandler struct {
+	slog.Handler
+	level *slog.LevelVar
+}
+
+// newSlogLevelHandler wraps the handler with the given level, replacing the level of the
+// handler if it is already wrapped.
+func newSlogLevelHandler(handler slog.Handler, level *slog.LevelVar) slog.Handler {
+	if levelHandler, ok := handler.(*slogLevelHandler); ok {
+		handler = levelHandler.Handler
+	}
+	return &slogLevelHandler{Handler: handler, level: level}
+}
+
+// Enabled reports whether the given level is enabled.
+func (h *slogLevelHandler) Enabled(ctx context.Context, lvl slog.Level) bool {
+	return lvl >= h.level.Level() && h.Handler.Enabled(ctx, lvl)
+}
+
+// WithAttrs adds the attributes to the wrapped handler, keeping the level.
+func (h *slogLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithAttrs(attrs), level: h.level}
+}
+
+// WithGroup adds the group to the wrapped handler, keeping the level.
+func (h *slogLevelHandler) WithGroup(name string) slog.Handler {
+	return &slogLevelHandler{Handler: h.Handler.WithGroup(name), level: h.level}
+}
+
+// replaceSlogLevelName names the levels that the slog package does not define,
+// which would otherwise be written as offsets, like "DEBUG-4" for the trace level.
+func replaceSlogLevelName(groups []string, a slog.Attr) slog.Attr {
+	if a.Key != slog.LevelKey || len(groups) > 0 {
+		return a
+	}
+
+	lvl, ok := a.Value.Any().(slog.Level)
+	if !ok {
+		return a
+	}
+
+	switch lvl {
+	case slogLevelTrace, slogLevelPanic, slogLevelFatal:
+		a.Value = slog.StringValue(strings.ToUpper(fromSlogLevel(lvl).String()))
+	}
+	return a
+}
+
+// toSlogLevel converts a Level to a slog.Level.
+func toSlogLevel(level Level) slog.Level {
+	switch level {
+	case LevelTrace:
+		return slogLevelTrace
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	case LevelPanic:
+		return slogLevelPanic
+	case LevelFatal:
+		return slogLevelFatal
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// fromSlogLevel converts a slog.Level to a Level.
+// Custom slog levels are rounded down to the closest known level.
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level >= slogLevelFatal:
+		return LevelFatal
+	case level >= slogLevelPanic:
+		return LevelPanic
+	case level >= slog.LevelError:
+		return LevelError
+	case level >= slog.LevelWarn:
+		return LevelWarn
+	case level >= slog.LevelInfo:
+		return LevelInfo
+	case level >= slog.LevelDebug:
+		return LevelDebug
+	default:
+		return LevelTrace
+	}
+}
@@ -0,0 +1,186 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// slogConsoleSeparator separates the parts of the console entries, like in the console format of the zap adapter.
+const slogConsoleSeparator = "  "
+
+// slogLevelColors are the ANSI color codes of the levels of the console entries, matching
+// the colors of the zap adapter. The trace level is not colored.
+var slogLevelColors = map[Level]int{
+	LevelDebug: 35, // magenta
+	LevelInfo:  34, // blue
+	LevelWarn:  33, // yellow
+	LevelError: 31, // red
+	LevelPanic: 31,
+	LevelFatal: 31,
+}
+
+// slogConsoleHandler is a slog.Handler that writes human-readable entries for development, like the
+// console format of the zap adapter: the time, level, logger name, source and message, followed by
+// the attributes as key=value pairs and the stack trace on the next lines.
+type slogConsoleHandler struct {
+	w         io.Writer
+	mu        *sync.Mutex
+	addSource bool
+	color     bool
+	// attrs are the attributes added with WithAttrs, already formatted.
+	attrs []byte
+	// prefix is the prefix of the keys of the attributes, built from the groups added with WithGroup.
+	prefix string
+}
+
+// newSlogConsoleHandler creates a console handler that writes the entries to w,
+// with the source of the entries if addSource is true and colored levels if color is true.
+func newSlogConsoleHandler(w io.Writer, addSource, color bool) *slogConsoleHandler {
+	return &slogConsoleHandler{
+		w:         w,
+		mu:        &sync.Mutex{},
+		addSource: addSource,
+		color:     color,
+	}
+}
+
+// Enabled reports whether the given level is enabled. Every level from trace is enabled,
+// since the level is checked by the slog adapter.
+func (h *slogConsoleHandler) Enabled(_ context.Context, lvl slog.Level) bool {
+	return lvl >= slogLevelTrace
+}
+
+// Handle writes the record as a single console entry.
+func (h *slogConsoleHandler) Handle(_ context.Context, r slog.Record) error {
+	// The logger name and the stack trace added by the slog adapter have their own place in the entry
+	var name, stacktrace string
+	attrs := h.attrs
+	r.Attrs(func(a slog.Attr) bool {
+		switch {
+		case h.prefix == "" && a.Key == slogLoggerNameKey:
+			name = a.Value.String()
+		case h.prefix == "" && a.Key == slogStacktraceKey:
+			stacktrace = a.Value.String()
+		default:
+			attrs = appendSlogConsoleAttr(attrs, h.prefix, a)
+		}
+		return true
+	})
+
+	buf := make([]byte, 0, 256)
+	if !r.Time.IsZero() {
+		buf = r.Time.AppendFormat(buf, logfmtTimeLayout)
+		buf = append(buf, slogConsoleSeparator...)
+	}
+	buf = h.appendLevel(buf, fromSlogLevel(r.Level))
+	if name != "" {
+		buf = append(buf, slogConsoleSeparator...)
+		buf = append(buf, name...)
+	}
+	if h.addSource && r.PC != 0 {
+		frame, _ := runtime.CallersFrames([]uintptr{r.PC}).Next()
+		buf = append(buf, slogConsoleSeparator...)
+		buf = append(buf, zapcore.NewEntryCaller(0, frame.File, frame.Line, true).TrimmedPath()...)
+	}
+	buf = append(buf, slogConsoleSeparator...)
+	buf = append(buf, r.Message...)
+	if len(attrs) > 0 {
+		// Every formatted attribute starts with a space
+		buf = append(buf, slogConsoleSeparator...)
+		buf = append(buf, attrs[1:]...)
+	}
+	buf = append(buf, '\n')
+	if stacktrace != "" {
+		buf = append(buf, stacktrace...)
+		buf = append(buf, '\n')
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := h.w.Write(buf)
+	return err
+}
+
+// WithAttrs returns a handler that adds the attributes to every entry.
+func (h *slogConsoleHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = h.attrs[:len(h.attrs):len(h.attrs)]
+	for _, a := range attrs {
+		clone.attrs = appendSlogConsoleAttr(clone.attrs, h.prefix, a)
+	}
+	return &clone
+}
+
+// WithGroup returns a handler that prefixes the keys of the attributes with the group name.
+func (h *slogConsoleHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// appendLevel appends the uppercase name of the level, colored if the colors are enabled.
+func (h *slogConsoleHandler) appendLevel(buf []byte, level Level) []byte {
+	name := strings.ToUpper(level.String())
+	color, ok := slogLevelColors[level]
+	if !h.color || !ok {
+		return append(buf, name...)
+	}
+	return fmt.Appendf(buf, "\x1b[%dm%s\x1b[0m", color, name)
+}
+
+// appendSlogConsoleAttr appends the attribute as a key=value pair preceded by a space, flattening
+// the groups using dotted keys, like the logfmt format.
+func appendSlogConsoleAttr(buf []byte, prefix string, a slog.Attr) []byte {
+	a.Value = a.Value.Resolve()
+	if a.Equal(slog.Attr{}) {
+		return buf
+	}
+
+	if a.Value.Kind() == slog.KindGroup {
+		if a.Key != "" {
+			prefix += a.Key + "."
+		}
+		for _, ga := range a.Value.Group() {
+			buf = appendSlogConsoleAttr(buf, prefix, ga)
+		}
+		return buf
+	}
+
+	buf = append(buf, ' ')
+	buf = append(buf, prefix...)
+	buf = append(buf, a.Key...)
+	buf = append(buf, '=')
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return appendSlogConsoleString(buf, a.Value.String())
+	case slog.KindTime:
+		return a.Value.Time().AppendFormat(buf, logfmtTimeLayout)
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return appendSlogConsoleString(buf, err.Error())
+		}
+		return appendSlogConsoleString(buf, fmt.Sprint(a.Value.Any()))
+	default:
+		return append(buf, a.Value.String()...)
+	}
+}
+
+// appendSlogConsoleString appends the string, quoted if it contains spaces or special characters.
+func appendSlogConsoleString(buf []byte, value string) []byte {
+	if needsQuoting(value) {
+		return strconv.AppendQuote(buf, value)
+	}
+	return append(buf, value...)
+}
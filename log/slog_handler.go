@@ -0,0 +1,133 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"strings"
+)
+
+// SlogHandler is a slog.Handler that forwards records to a Logger.
+// It allows libraries that log through log/slog to share the same output, level and
+// context enrichment (like trace ids) as the rest of the application.
+type SlogHandler struct {
+	logger Logger
+	groups []string
+}
+
+// NewSlogHandler creates a new slog.Handler that forwards every record to the provided logger.
+// Usage:
+//
+//	logger, _ := log.New(log.WithAdapter(log.AdapterZap))
+//	slog.SetDefault(slog.New(log.NewSlogHandler(logger)))
+func NewSlogHandler(logger Logger) *SlogHandler {
+	return &SlogHandler{
+		logger: logger,
+	}
+}
+
+// ToSlog returns a slog.Logger that writes its records to the provided logger.
+func ToSlog(logger Logger) *slog.Logger {
+	return slog.New(NewSlogHandler(logger))
+}
+
+// Enabled reports whether the underlying logger handles records at the given level.
+func (h *SlogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return fromSlogLevel(level) >= h.logger.GetLevel()
+}
+
+// Handle converts the record attributes into fields and logs the record with the underlying logger,
+// keeping the time and the source of the record.
+func (h *SlogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make([]Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = append(fields, fromSlogAttr(h.prefix(), attr)...)
+		return true
+	})
+
+	// The entry is not terminating, like with Log, so records with custom levels above the error
+	// level do not terminate the application. The skipped frames are the ones of the slog.Logger
+	// methods, like Info and log, used for the stack traces.
+	writeEntry(ctx, h.logger, entry{
+		level:  fromSlogLevel(record.Level),
+		msg:    record.Message,
+		fields: fields,
+		time:   record.Time,
+		pc:     record.PC,
+		skip:   3,
+	})
+	return nil
+}
+
+// WithAttrs returns a new handler whose logger includes the given attributes.
+func (h *SlogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make([]Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = append(fields, fromSlogAttr(h.prefix(), attr)...)
+	}
+
+	return &SlogHandler{
+		logger: h.logger.With(fields...),
+		groups: h.groups,
+	}
+}
+
+// WithGroup returns a new handler that qualifies the keys of subsequent attributes with the group name.
+func (h *SlogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+
+	return &SlogHandler{
+		logger: h.logger,
+		groups: append(h.groups[:len(h.groups):len(h.groups)], name),
+	}
+}
+
+// prefix returns the key prefix for the currently open groups.
+func (h *SlogHandler) prefix() string {
+	if len(h.groups) == 0 {
+		return ""
+	}
+	return strings.Join(h.groups, ".") + "."
+}
+
+// fromSlogAttr converts a slog attribute to log fields.
+// Groups are flattened, using the group name as prefix for the keys of its attributes.
+func fromSlogAttr(prefix string, attr slog.Attr) []Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return nil
+	}
+
+	key := prefix + attr.Key
+
+	switch attr.Value.Kind() {
+	case slog.KindGroup:
+		groupPrefix := prefix
+		if attr.Key != "" {
+			groupPrefix = key + "."
+		}
+
+		var fields []Field
+		for _, groupAttr := range attr.Value.Group() {
+			fields = append(fields, fromSlogAttr(groupPrefix, groupAttr)...)
+		}
+		return fields
+	case slog.KindString:
+		return []Field{String(key, attr.Value.String())}
+	case slog.KindInt64:
+		return []Field{Int64(key, attr.Value.Int64())}
+	case slog.KindFloat64:
+		return []Field{Float64(key, attr.Value.Float64())}
+	case slog.KindBool:
+		return []Field{Bool(key, attr.Value.Bool())}
+	case slog.KindUint64:
+		return []Field{Uint64(key, attr.Value.Uint64())}
+	case slog.KindDuration:
+		return []Field{Duration(key, attr.Value.Duration())}
+	case slog.KindTime:
+		return []Field{Time(key, attr.Value.Time())}
+	default:
+		return []Field{Any(key, attr.Value.Any())}
+	}
+}
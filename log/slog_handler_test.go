@@ -0,0 +1,154 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+func TestSlogHandler_Enabled(t *testing.T) {
+	t.Parallel()
+
+	handler := log.NewSlogHandler(log.NewInMemory(log.LevelWarn))
+
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelDebug))
+	assert.False(t, handler.Enabled(context.Background(), slog.LevelInfo))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, handler.Enabled(context.Background(), slog.LevelError))
+}
+
+func TestSlogHandler_Handle(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		level    slog.Level
+		expected string
+	}{
+		{name: "Trace", level: slog.LevelDebug - 4, expected: "trace"},
+		{name: "Debug", level: slog.LevelDebug, expected: "debug"},
+		{name: "Info", level: slog.LevelInfo, expected: "info"},
+		{name: "Warn", level: slog.LevelWarn, expected: "warn"},
+		{name: "Error", level: slog.LevelError, expected: "error"},
+		{name: "CustomLevel", level: slog.LevelError + 2, expected: "error"},
+		{name: "Panic", level: slog.LevelError + 4, expected: "panic"},
+		{name: "Fatal", level: slog.LevelError + 8, expected: "fatal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			logger := log.NewInMemory(log.LevelTrace)
+			slogger := log.ToSlog(logger)
+
+			// Panic and fatal records are logged without terminating the application.
+			slogger.Log(context.Background(), tt.level, "message", slog.String("key", "value"))
+
+			entries := logger.Entries()
+			require.Len(t, entries, 1)
+			assert.Equal(t, tt.expected, entries[0].Level)
+			assert.Equal(t, "message", entries[0].Message)
+
+			field, ok := entries[0].GetField("key")
+			require.True(t, ok)
+			assert.Equal(t, "value", field.String)
+		})
+	}
+}
+
+func TestSlogHandler_RespectsLoggerLevel(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewInMemory(log.LevelInfo)
+	slogger := log.ToSlog(logger)
+
+	slogger.Debug("debug message")
+	assert.Empty(t, logger.Entries())
+
+	require.NoError(t, logger.SetLevel(log.LevelDebug))
+	slogger.Debug("debug message")
+	assert.Len(t, logger.Entries(), 1)
+}
+
+func TestSlogHandler_Attributes(t *testing.T) {
+	t.Parallel()
+
+	logger := log.NewInMemory(log.LevelDebug)
+	slogger := log.ToSlog(logger)
+
+	slogger.Info("message",
+		slog.Int("count", 3),
+		slog.Bool("enabled", true),
+		slog.Duration("elapsed", time.Second),
+		slog.Group("request", slog.String("method", "GET")),
+		slog.Attr{},
+	)
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Len(t, entries[0].Fields, 4)
+
+	count, ok := entries[0].GetField("count")
+	require.True(t, ok)
+	assert.Equal(t, int64(3), count.Integer)
+
+	method, ok := entries[0].GetField("request.method")
+	require.True(t, ok)
+	assert.Equal(t, "GET", method.String)
+}
+
+func TestSlogHandler_WithAttrsAndGroup(t *testing.T) {
+	t.Parallel()
+
+	buf := &bytes.Buffer{}
+	logger := createTestLogger(buf, zapcore.DebugLevel)
+	slogger := log.ToSlog(logger).
+		With(slog.String("component", "db")).
+		WithGroup("query").
+		With(slog.String("table", "users"))
+
+	slogger.Info("message", slog.String("status", "ok"))
+
+	output := buf.String()
+	assertContainsJSONField(t, output, "msg", "message")
+	assertContainsJSONField(t, output, "component", "db")
+	assertContainsJSONField(t, output, "query.table", "users")
+	assertContainsJSONField(t, output, "query.status", "ok")
+}
+
+func TestSlogHandler_Time(t *testing.T) {
+	t.Parallel()
+	logger := log.NewInMemory(log.LevelInfo)
+	handler := log.NewSlogHandler(logger)
+
+	recordTime := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	require.NoError(t, handler.Handle(context.Background(), slog.NewRecord(recordTime, slog.LevelInfo, "message", 0)))
+
+	entries := logger.Entries()
+	require.Len(t, entries, 1)
+	assert.Equal(t, recordTime, entries[0].Timestamp)
+}
+
+func TestSlogHandler_Caller(t *testing.T) {
+	t.Parallel()
+
+	loggers, read := newConsoleFileLoggers(t)
+	ctx := context.Background()
+	for _, inner := range loggers {
+		slogger := log.ToSlog(inner)
+		slogger.Info("info message")
+		slogger.With(slog.String("key", "value")).WarnContext(ctx, "warn message")
+		slogger.Log(ctx, slog.LevelError+4, "panic message")
+		require.NoError(t, inner.Sync())
+	}
+
+	assertCallers(t, read(), 3, "slog_handler_test.go")
+}
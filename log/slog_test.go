@@ -0,0 +1,272 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// createTestSlogLogger creates a SlogAdapter that writes JSON to the provided buffer.
+func createTestSlogLogger(buf *bytes.Buffer, lvl slog.Level) *log.SlogAdapter {
+	levelVar := &slog.LevelVar{}
+	levelVar.Set(lvl)
+
+	return &log.SlogAdapter{
+		SlogL:    slog.New(slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: levelVar})),
+		LevelVar: levelVar,
+	}
+}
+
+func TestSlogAdapter_New(t *testing.T) {
+	t.Parallel()
+
+	t.Run("WithDefaultOptions", func(t *testing.T) {
+		t.Parallel()
+		logger, err := log.NewSlog(log.DefaultSlogLoggerOpts())
+
+		require.NoError(t, err)
+		assert.IsType(t, &log.SlogAdapter{}, logger)
+		assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	})
+
+	t.Run("WithLogFmtFormat", func(t *testing.T) {
+		t.Parallel()
+		logger, err := log.NewSlog(log.SlogLoggerOpts{
+			Level:   log.LevelDebug,
+			Profile: log.ProfileDevelopment,
+			Format:  log.FormatLogFmt,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, log.LevelDebug, logger.GetLevel())
+	})
+
+	t.Run("WithInvalidProfile", func(t *testing.T) {
+		t.Parallel()
+		_, err := log.NewSlog(log.SlogLoggerOpts{
+			Profile: "invalid",
+			Format:  log.FormatJSON,
+		})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, log.ErrInvalidProfile)
+	})
+
+	t.Run("WithInvalidFormat", func(t *testing.T) {
+		t.Parallel()
+		_, err := log.NewSlog(log.SlogLoggerOpts{
+			Profile: log.ProfileProduction,
+			Format:  "invalid",
+		})
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, log.ErrInvalidFormat)
+	})
+}
+
+func TestSlogAdapter_Levels(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		level string
+		log   func(l log.Logger, ctx context.Context)
+	}{
+		{name: "Debug", level: "DEBUG", log: func(l log.Logger, ctx context.Context) { l.Debug(ctx, "message", log.String("key", "value")) }},
+		{name: "Info", level: "INFO", log: func(l log.Logger, ctx context.Context) { l.Info(ctx, "message", log.String("key", "value")) }},
+		{name: "Warn", level: "WARN", log: func(l log.Logger, ctx context.Context) { l.Warn(ctx, "message", log.String("key", "value")) }},
+		{name: "Error", level: "ERROR", log: func(l log.Logger, ctx context.Context) { l.Error(ctx, "message", log.String("key", "value")) }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			buf := &bytes.Buffer{}
+			logger := createTestSlogLogger(buf, slog.LevelDebug)
+
+			tt.log(logger, context.Background())
+
+			output := buf.String()
+			assertContainsJSONField(t, output, "level", tt.level)
+			assertContainsJSONField(t, output, "msg", "message")
+			assertContainsJSONField(t, output, "key", "value")
+		})
+	}
+}
+
+func TestSlogAdapter_LevelNames(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := log.NewSlog(log.SlogLoggerOpts{
+		Level:   log.LevelTrace,
+		Profile: log.ProfileProduction,
+		Format:  log.FormatJSON,
+		Outputs: []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, log.LevelTrace, logger.GetLevel())
+
+	ctx := context.Background()
+	logger.Trace(ctx, "trace message")
+	logger.Log(ctx, log.LevelPanic, "panic message")
+	logger.Log(ctx, log.LevelFatal, "fatal message")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	require.Len(t, lines, 3)
+	assertContainsJSONField(t, lines[0], "level", "TRACE")
+	assertContainsJSONField(t, lines[1], "level", "PANIC")
+	assertContainsJSONField(t, lines[2], "level", "FATAL")
+}
+
+func TestSlogAdapter_ConsoleFormat(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := log.NewSlog(log.SlogLoggerOpts{
+		Level:             log.LevelInfo,
+		Profile:           log.ProfileDevelopment,
+		Outputs:           []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+		DisableStacktrace: true,
+	})
+	require.NoError(t, err)
+
+	logger.Named("db").With(log.String("table", "users")).Warn(context.Background(), "query failed",
+		log.String("query", "SELECT 1"), log.Group("conn", log.Int("id", 7)))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	// The development profile defaults to the console format, with the source of the entry
+	assert.Regexp(t,
+		`^\d{4}-\d{2}-\d{2}T\S+  WARN  db  log/slog_test\.go:\d+  query failed  table=users query="SELECT 1" conn\.id=7\n$`,
+		string(content))
+}
+
+func TestSlogAdapter_Named(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := log.NewSlog(log.SlogLoggerOpts{
+		Level:   log.LevelInfo,
+		Profile: log.ProfileProduction,
+		Format:  log.FormatJSON,
+		Outputs: []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+	})
+	require.NoError(t, err)
+
+	dbLogger := logger.Named("db").Named("migrations")
+	require.NoError(t, log.RegistryOf(logger).SetLevel("db.migrations", log.LevelDebug))
+
+	ctx := context.Background()
+	dbLogger.Debug(ctx, "db debug message")
+	logger.Debug(ctx, "discarded debug message")
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	assertContainsJSONField(t, string(content), "logger", "db.migrations")
+	assertContainsJSONField(t, string(content), "msg", "db debug message")
+}
+
+func TestSlogAdapter_Panic(t *testing.T) {
+	t.Parallel()
+	buf := &bytes.Buffer{}
+	logger := createTestSlogLogger(buf, slog.LevelInfo)
+
+	assert.PanicsWithValue(t, "panic message", func() {
+		logger.Panic(context.Background(), "panic message")
+	})
+	assertContainsJSONField(t, buf.String(), "msg", "panic message")
+}
+
+func TestSlogAdapter_SetLevel(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := createTestSlogLogger(buf, slog.LevelInfo)
+
+	logger.Debug(context.Background(), "debug message")
+	assert.Empty(t, buf.String())
+
+	require.NoError(t, logger.SetLevel(log.LevelDebug))
+	assert.Equal(t, log.LevelDebug, logger.GetLevel())
+
+	logger.Debug(context.Background(), "debug message")
+	assertContainsJSONField(t, buf.String(), "msg", "debug message")
+}
+
+func TestSlogAdapter_WithFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := createTestSlogLogger(buf, slog.LevelDebug)
+
+	childLogger := logger.With(log.String("field1", "value1"))
+	assert.IsType(t, &log.SlogAdapter{}, childLogger)
+
+	childLogger.Info(context.Background(), "info message")
+
+	output := buf.String()
+	assertContainsJSONField(t, output, "field1", "value1")
+	assertContainsJSONField(t, output, "msg", "info message")
+
+	// Child loggers share the level with the parent logger
+	require.NoError(t, logger.SetLevel(log.LevelError))
+	assert.Equal(t, log.LevelError, childLogger.GetLevel())
+}
+
+func TestSlogAdapter_TraceFields(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := createTestSlogLogger(buf, slog.LevelDebug)
+
+	spanCtx := trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID: trace.TraceID([16]byte{1, 2, 3, 4}),
+		SpanID:  trace.SpanID([8]byte{1, 2, 3, 4}),
+	})
+	ctx := trace.ContextWithSpanContext(context.Background(), spanCtx)
+
+	logger.Info(ctx, "info message")
+
+	output := buf.String()
+	assertContainsJSONField(t, output, "traceId", spanCtx.TraceID().String())
+	assertContainsJSONField(t, output, "spanId", spanCtx.SpanID().String())
+}
+
+func TestSlogAdapter_Sync(t *testing.T) {
+	logger, err := log.NewSlog(log.DefaultSlogLoggerOpts())
+	require.NoError(t, err)
+	assert.NoError(t, logger.Sync())
+}
+
+func TestSlogAdapter_FieldEncoding(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := createTestSlogLogger(buf, slog.LevelDebug)
+
+	logger.Info(context.Background(), "info message",
+		log.Err(errors.New("boom")),
+		log.NamedErr("cause", nil),
+		log.Bool("enabled", true),
+		log.Group("request", log.String("method", "GET"), log.Int("status", 200)),
+	)
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+
+	assert.Equal(t, "boom", data["error"])
+	assert.NotContains(t, data, "cause")
+	assert.Equal(t, true, data["enabled"])
+	assert.Equal(t, map[string]any{"method": "GET", "status": float64(200)}, data["request"])
+}
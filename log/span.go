@@ -0,0 +1,203 @@
+package log
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// spanEventSeverityKey is the key of the span event attribute with the level of the entry.
+const spanEventSeverityKey = "log.severity"
+
+// WithSpanEvents records the entries at the Warn level and above as events of the span in the
+// context, if it is recording. See NewSpanEventLogger.
+func WithSpanEvents() LoggerOpt {
+	return func(o *LoggerOpts) {
+		o.SpanEvents = true
+	}
+}
+
+// SpanEventLogger is a logger that records the entries at the Warn level and above as events of the
+// span in the context, with the fields as attributes, before writing them to the underlying logger.
+// Entries at the Error level and above also set the status of the span to error, and the first
+// error field is recorded in the span, as an exception event. It can wrap any Logger implementation.
+type SpanEventLogger struct {
+	logger Logger
+}
+
+// NewSpanEventLogger creates a new logger that records the entries as events of the active span.
+// Usage:
+//
+//	logger := log.NewSpanEventLogger(zapLogger)
+//	ctx, span := tracer.Start(ctx, "checkout")
+//	logger.Error(ctx, "payment failed", log.Err(err)) // adds a span event and sets the span status
+func NewSpanEventLogger(logger Logger) *SpanEventLogger {
+	return &SpanEventLogger{
+		logger: logger,
+	}
+}
+
+// record adds the entry as an event of the recording span in the context, if its level is enabled.
+func (l *SpanEventLogger) record(ctx context.Context, level Level, msg string, fields []Field) {
+	if ctx == nil || level < LevelWarn || level < l.logger.GetLevel() {
+		return
+	}
+
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	attrs := []attribute.KeyValue{attribute.String(spanEventSeverityKey, level.String())}
+	span.AddEvent(msg, trace.WithAttributes(appendSpanAttributes(attrs, "", fields)...))
+
+	if level < LevelError {
+		return
+	}
+
+	span.SetStatus(codes.Error, msg)
+	for _, f := range fields {
+		if err, ok := f.Interface.(error); ok && f.Type == ErrorType && err != nil {
+			span.RecordError(err)
+			break
+		}
+	}
+}
+
+// Info logs an informational message.
+func (l *SpanEventLogger) Info(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelInfo, msg: msg, fields: fields, skip: 1})
+}
+
+// Warn logs a warning message and records it in the active span.
+func (l *SpanEventLogger) Warn(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelWarn, msg: msg, fields: fields, skip: 1})
+}
+
+// Error logs an error message and records it in the active span, setting its status to error.
+func (l *SpanEventLogger) Error(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelError, msg: msg, fields: fields, skip: 1})
+}
+
+// Debug logs a debug message.
+func (l *SpanEventLogger) Debug(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelDebug, msg: msg, fields: fields, skip: 1})
+}
+
+// Trace logs a trace message.
+func (l *SpanEventLogger) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelTrace, msg: msg, fields: fields, skip: 1})
+}
+
+// Panic records the message in the active span, logs it and then panics.
+func (l *SpanEventLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelPanic, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Fatal records the message in the active span, logs it and then exits.
+func (l *SpanEventLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: LevelFatal, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Log logs a message at the given level, recording it in the active span when the level is Warn or above.
+func (l *SpanEventLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	l.writeEntry(ctx, entry{level: level, msg: msg, fields: fields, skip: 1})
+}
+
+// writeEntry records the entry in the active span and writes it to the underlying logger, keeping its caller.
+func (l *SpanEventLogger) writeEntry(ctx context.Context, e entry) {
+	l.record(ctx, e.level, e.msg, e.fields)
+	// Skip this method too
+	e.skip++
+	writeEntry(ctx, l.logger, e)
+}
+
+// SetLevel updates the level of the underlying logger.
+func (l *SpanEventLogger) SetLevel(level Level) error {
+	return l.logger.SetLevel(level)
+}
+
+// GetLevel returns the level of the underlying logger.
+func (l *SpanEventLogger) GetLevel() Level {
+	return l.logger.GetLevel()
+}
+
+// With returns a new logger with the additional fields, that records the entries in the active span.
+// The additional fields are written by the underlying logger, but are not added to the span events.
+func (l *SpanEventLogger) With(fields ...Field) Logger {
+	return &SpanEventLogger{
+		logger: l.logger.With(fields...),
+	}
+}
+
+// Named returns a new named logger, that records the entries in the active span.
+func (l *SpanEventLogger) Named(name string) Logger {
+	return &SpanEventLogger{
+		logger: l.logger.Named(name),
+	}
+}
+
+// Registry returns the registry of the named loggers created from the underlying logger.
+func (l *SpanEventLogger) Registry() *LevelRegistry {
+	return RegistryOf(l.logger)
+}
+
+// Sync flushes the underlying logger.
+func (l *SpanEventLogger) Sync() error {
+	return l.logger.Sync()
+}
+
+// Close closes the underlying logger. See Close.
+func (l *SpanEventLogger) Close() error {
+	return Close(l.logger)
+}
+
+// appendSpanAttributes converts the fields to span attributes and appends them to attrs.
+// Groups and objects are flattened, with their keys prefixed by the key of the group and a dot.
+func appendSpanAttributes(attrs []attribute.KeyValue, prefix string, fields []Field) []attribute.KeyValue {
+	for _, f := range expandErrorFields(fields) {
+		key := prefix + f.Key
+		switch f.Type {
+		case StringType:
+			attrs = append(attrs, attribute.String(key, f.String))
+		case BoolType:
+			attrs = append(attrs, attribute.Bool(key, f.Integer == 1))
+		case Int64Type:
+			attrs = append(attrs, attribute.Int64(key, f.Integer))
+		case Uint64Type:
+			if v := uint64(f.Integer); v > math.MaxInt64 {
+				attrs = append(attrs, attribute.String(key, strconv.FormatUint(v, 10)))
+				continue
+			}
+			attrs = append(attrs, attribute.Int64(key, f.Integer))
+		case Float64Type, Float32Type:
+			attrs = append(attrs, attribute.Float64(key, f.Float))
+		case DurationType:
+			attrs = append(attrs, attribute.String(key, time.Duration(f.Integer).String()))
+		case TimeType:
+			t, _ := f.Interface.(time.Time)
+			attrs = append(attrs, attribute.String(key, t.Format(time.RFC3339Nano)))
+		case ErrorType:
+			if err, ok := f.Interface.(error); ok && err != nil {
+				attrs = append(attrs, attribute.String(key, err.Error()))
+			}
+		case StringerType:
+			attrs = append(attrs, attribute.String(key, stringerValue(f.Interface)))
+		case GroupType, ObjectType:
+			attrs = appendSpanAttributes(attrs, key+".", f.Fields())
+		default:
+			if values, ok := f.Interface.([]string); ok {
+				attrs = append(attrs, attribute.StringSlice(key, values))
+				continue
+			}
+			attrs = append(attrs, attribute.String(key, fmt.Sprint(f.Interface)))
+		}
+	}
+	return attrs
+}
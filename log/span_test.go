@@ -0,0 +1,147 @@
+package log_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// recordSpan runs fn with the context of a recording span and returns the ended span.
+func recordSpan(t *testing.T, fn func(ctx context.Context)) sdktrace.ReadOnlySpan {
+	t.Helper()
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	ctx, span := tracer.Start(context.Background(), "operation")
+	fn(ctx)
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	return spans[0]
+}
+
+func TestSpanEventLogger_Warn(t *testing.T) {
+	t.Parallel()
+	inner := log.NewInMemory(log.LevelInfo)
+	logger := log.NewSpanEventLogger(inner)
+
+	span := recordSpan(t, func(ctx context.Context) {
+		logger.Info(ctx, "not recorded")
+		logger.Warn(ctx, "slow query", log.Int("rows", 10), log.Group("db", log.String("table", "users")))
+	})
+
+	require.Len(t, span.Events(), 1)
+	event := span.Events()[0]
+	assert.Equal(t, "slow query", event.Name)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("log.severity", "warn"),
+		attribute.Int64("rows", 10),
+		attribute.String("db.table", "users"),
+	}, event.Attributes)
+	assert.Equal(t, codes.Unset, span.Status().Code)
+	assert.Len(t, inner.Entries(), 2)
+}
+
+func TestSpanEventLogger_Error(t *testing.T) {
+	t.Parallel()
+	logger := log.NewSpanEventLogger(log.NewInMemory(log.LevelInfo))
+	err := errors.New("connection refused")
+
+	span := recordSpan(t, func(ctx context.Context) {
+		logger.Error(ctx, "payment failed", log.Err(err))
+	})
+
+	assert.Equal(t, codes.Error, span.Status().Code)
+	assert.Equal(t, "payment failed", span.Status().Description)
+
+	require.Len(t, span.Events(), 2)
+	assert.Equal(t, "payment failed", span.Events()[0].Name)
+	assert.Contains(t, span.Events()[0].Attributes, attribute.String("error", "connection refused"))
+	assert.Equal(t, "exception", span.Events()[1].Name)
+}
+
+func TestSpanEventLogger_DisabledLevel(t *testing.T) {
+	t.Parallel()
+	logger := log.NewSpanEventLogger(log.NewInMemory(log.LevelError))
+
+	span := recordSpan(t, func(ctx context.Context) {
+		logger.Warn(ctx, "discarded warning")
+	})
+
+	assert.Empty(t, span.Events())
+}
+
+func TestSpanEventLogger_WithoutSpan(t *testing.T) {
+	t.Parallel()
+	inner := log.NewInMemory(log.LevelInfo)
+	logger := log.NewSpanEventLogger(inner)
+
+	assert.NotPanics(t, func() {
+		logger.Error(context.Background(), "no span")
+		logger.Named("db").With(log.String("key", "value")).Error(context.Background(), "no span")
+	})
+	assert.Len(t, inner.Entries(), 2)
+	assert.Equal(t, []string{"db"}, log.RegistryOf(logger).Names())
+}
+
+func TestNew_WithSpanEvents(t *testing.T) {
+	t.Parallel()
+
+	logger, err := log.New(log.WithAdapter(log.AdapterInMemory), log.WithSpanEvents())
+	require.NoError(t, err)
+
+	assert.IsType(t, &log.SpanEventLogger{}, logger)
+}
+
+func TestSpanEventLogger_Caller(t *testing.T) {
+	t.Parallel()
+
+	loggers, read := newConsoleFileLoggers(t)
+	span := recordSpan(t, func(ctx context.Context) {
+		for _, inner := range loggers {
+			logger := log.NewSpanEventLogger(inner)
+			logger.Info(ctx, "info message")
+			logger.With(log.String("key", "value")).Log(ctx, log.LevelWarn, "warn message")
+			assert.Panics(t, func() {
+				logger.Panic(ctx, "panic message")
+			})
+			require.NoError(t, logger.Sync())
+		}
+	})
+
+	assert.Len(t, span.Events(), 4)
+	assertCallers(t, read(), 3, "span_test.go")
+}
+
+func TestNew_WithSpanEventsAndRedaction(t *testing.T) {
+	t.Parallel()
+
+	logger, err := log.New(
+		log.WithAdapter(log.AdapterInMemory),
+		log.WithSpanEvents(),
+		log.WithRedaction(log.DefaultRedactionOpts()),
+	)
+	require.NoError(t, err)
+
+	span := recordSpan(t, func(ctx context.Context) {
+		logger.Error(ctx, "payment failed", log.Err(errors.New("card of john@example.com refused")))
+	})
+
+	// The error is still recorded, with the redacted message
+	require.Len(t, span.Events(), 2)
+	assert.Equal(t, "payment failed", span.Events()[0].Name)
+	assert.Contains(t, span.Events()[0].Attributes, attribute.String("error", "card of [REDACTED] refused"))
+	assert.Equal(t, "exception", span.Events()[1].Name)
+	assert.Contains(t, span.Events()[1].Attributes, attribute.String("exception.message", "card of [REDACTED] refused"))
+}
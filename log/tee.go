@@ -0,0 +1,167 @@
+package log
+
+import (
+	"context"
+	"errors"
+)
+
+// TeeLogger is a logger that fans out every log entry to multiple loggers.
+// Each logger keeps its own level, so the same entry can be written by some loggers and
+// discarded by others.
+type TeeLogger struct {
+	loggers  []Logger
+	name     string
+	registry *LevelRegistry
+}
+
+// NewTee creates a new logger that writes every entry to all the provided loggers.
+// Nil loggers are ignored.
+// Usage:
+//
+//	stdoutLogger, _ := log.New(log.WithLevel(log.LevelInfo), log.WithStdout())
+//	fileLogger, _ := log.New(log.WithLevel(log.LevelDebug), log.WithRotatingFile(log.FileOutputOpts{Path: "debug.log"}))
+//	logger := log.NewTee(stdoutLogger, fileLogger)
+//	logger.Debug(context.Background(), "only written to the file")
+func NewTee(loggers ...Logger) *TeeLogger {
+	tee := &TeeLogger{
+		loggers:  make([]Logger, 0, len(loggers)),
+		registry: NewLevelRegistry(),
+	}
+	for _, logger := range loggers {
+		if logger != nil {
+			tee.loggers = append(tee.loggers, logger)
+		}
+	}
+	return tee
+}
+
+// Loggers returns the loggers the entries are written to.
+func (t *TeeLogger) Loggers() []Logger {
+	return t.loggers
+}
+
+// Info logs an informational message in all the loggers.
+func (t *TeeLogger) Info(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelInfo, msg: msg, fields: fields, skip: 1})
+}
+
+// Warn logs a warning message in all the loggers.
+func (t *TeeLogger) Warn(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelWarn, msg: msg, fields: fields, skip: 1})
+}
+
+// Error logs an error message in all the loggers.
+func (t *TeeLogger) Error(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelError, msg: msg, fields: fields, skip: 1})
+}
+
+// Debug logs a debug message in all the loggers.
+func (t *TeeLogger) Debug(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelDebug, msg: msg, fields: fields, skip: 1})
+}
+
+// Trace logs a trace message in all the loggers.
+func (t *TeeLogger) Trace(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelTrace, msg: msg, fields: fields, skip: 1})
+}
+
+// Panic logs a panic message in all the loggers and then panics.
+func (t *TeeLogger) Panic(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelPanic, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Fatal logs a fatal message in all the loggers, flushes them and then calls os.Exit(1).
+func (t *TeeLogger) Fatal(ctx context.Context, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: LevelFatal, msg: msg, fields: fields, skip: 1, terminate: true})
+}
+
+// Log logs a message at the given level in all the loggers, without panicking or exiting.
+func (t *TeeLogger) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	t.writeEntry(ctx, entry{level: level, msg: msg, fields: fields, skip: 1})
+}
+
+// writeEntry writes the entry in all the loggers, keeping its caller, and then panics or
+// exits for terminating entries, once every logger wrote the entry.
+func (t *TeeLogger) writeEntry(ctx context.Context, e entry) {
+	terminating := e.terminate
+	e.terminate = false
+	// Skip this method too
+	e.skip++
+	for _, logger := range t.loggers {
+		writeEntry(ctx, logger, e)
+	}
+
+	if terminating {
+		terminate(t, e.level, e.msg)
+	}
+}
+
+// SetLevel updates the level of all the loggers.
+// To change the level of a single logger, call SetLevel on that logger directly.
+func (t *TeeLogger) SetLevel(level Level) error {
+	var err error
+	for _, logger := range t.loggers {
+		err = errors.Join(err, logger.SetLevel(level))
+	}
+	return err
+}
+
+// GetLevel returns the most verbose level among all the loggers, which is the
+// lowest level at which at least one logger writes the entries.
+// Returns LevelInfo if there are no loggers.
+func (t *TeeLogger) GetLevel() Level {
+	if len(t.loggers) == 0 {
+		return LevelInfo
+	}
+
+	level := t.loggers[0].GetLevel()
+	for _, logger := range t.loggers[1:] {
+		level = min(level, logger.GetLevel())
+	}
+	return level
+}
+
+// With returns a new logger that adds the fields to all the loggers.
+func (t *TeeLogger) With(fields ...Field) Logger {
+	loggers := make([]Logger, len(t.loggers))
+	for i, logger := range t.loggers {
+		loggers[i] = logger.With(fields...)
+	}
+	return &TeeLogger{loggers: loggers, name: t.name, registry: t.registry}
+}
+
+// Named returns a new logger that writes to the named child of each logger.
+// Changing the level of the returned logger changes the level of all the named children.
+func (t *TeeLogger) Named(name string) Logger {
+	fullName := joinLoggerName(t.name, name)
+	return t.registry.register(fullName, func(Logger) Logger {
+		loggers := make([]Logger, len(t.loggers))
+		for i, logger := range t.loggers {
+			loggers[i] = logger.Named(name)
+		}
+		return &TeeLogger{loggers: loggers, name: fullName, registry: t.registry}
+	})
+}
+
+// Registry returns the registry of the named loggers created from this logger.
+func (t *TeeLogger) Registry() *LevelRegistry {
+	return t.registry
+}
+
+// Sync flushes all the loggers, returning the errors of all of them.
+func (t *TeeLogger) Sync() error {
+	var err error
+	for _, logger := range t.loggers {
+		err = errors.Join(err, logger.Sync())
+	}
+	return err
+}
+
+// Close closes all the underlying loggers. See Close.
+func (t *TeeLogger) Close() error {
+	var err error
+	for _, logger := range t.loggers {
+		err = errors.Join(err, Close(logger))
+	}
+	return err
+}
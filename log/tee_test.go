@@ -0,0 +1,183 @@
+package log_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// failingSyncLogger is a logger whose Sync always fails.
+type failingSyncLogger struct {
+	log.NopLogger
+	err error
+}
+
+func (l *failingSyncLogger) Sync() error {
+	return l.err
+}
+
+func TestNewTee(t *testing.T) {
+	t.Parallel()
+
+	tee := log.NewTee(log.NewNop(), nil, log.NewInMemory(log.LevelDebug))
+
+	assert.Len(t, tee.Loggers(), 2)
+}
+
+func TestTeeLogger_PerLoggerLevels(t *testing.T) {
+	t.Parallel()
+
+	infoLogger := log.NewInMemory(log.LevelInfo)
+	debugLogger := log.NewInMemory(log.LevelDebug)
+	tee := log.NewTee(infoLogger, debugLogger)
+
+	ctx := context.Background()
+	tee.Debug(ctx, "debug message")
+	tee.Info(ctx, "info message")
+	tee.Warn(ctx, "warn message")
+	tee.Error(ctx, "error message")
+
+	assert.Len(t, infoLogger.Entries(), 3)
+	assert.Len(t, debugLogger.Entries(), 4)
+}
+
+func TestTeeLogger_GetLevel(t *testing.T) {
+	t.Parallel()
+
+	t.Run("ReturnsMostVerboseLevel", func(t *testing.T) {
+		t.Parallel()
+		tee := log.NewTee(log.NewInMemory(log.LevelError), log.NewInMemory(log.LevelDebug))
+		assert.Equal(t, log.LevelDebug, tee.GetLevel())
+	})
+
+	t.Run("NoLoggers", func(t *testing.T) {
+		t.Parallel()
+		assert.Equal(t, log.LevelInfo, log.NewTee().GetLevel())
+	})
+}
+
+func TestTeeLogger_SetLevel(t *testing.T) {
+	t.Parallel()
+
+	first := log.NewInMemory(log.LevelInfo)
+	second := log.NewInMemory(log.LevelDebug)
+	tee := log.NewTee(first, second)
+
+	require.NoError(t, tee.SetLevel(log.LevelWarn))
+
+	assert.Equal(t, log.LevelWarn, first.GetLevel())
+	assert.Equal(t, log.LevelWarn, second.GetLevel())
+}
+
+func TestTeeLogger_With(t *testing.T) {
+	t.Parallel()
+
+	firstBuf := &bytes.Buffer{}
+	secondBuf := &bytes.Buffer{}
+	tee := log.NewTee(
+		createTestLogger(firstBuf, zapcore.DebugLevel),
+		createTestLogger(secondBuf, zapcore.DebugLevel),
+	)
+
+	child := tee.With(log.String("key", "value"))
+	assert.IsType(t, &log.TeeLogger{}, child)
+
+	child.Info(context.Background(), "info message")
+
+	assertContainsJSONField(t, firstBuf.String(), "key", "value")
+	assertContainsJSONField(t, secondBuf.String(), "key", "value")
+}
+
+func TestTeeLogger_Sync(t *testing.T) {
+	t.Parallel()
+
+	errFirst := errors.New("first")
+	errSecond := errors.New("second")
+	tee := log.NewTee(
+		&failingSyncLogger{err: errFirst},
+		log.NewNop(),
+		&failingSyncLogger{err: errSecond},
+	)
+
+	err := tee.Sync()
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, err, errSecond)
+}
+
+// newConsoleFileLoggers creates zap and slog loggers writing in the console format to files, which
+// include the caller of the entries, returning the loggers and a function reading the files.
+func newConsoleFileLoggers(t *testing.T) ([]log.Logger, func() []string) {
+	t.Helper()
+
+	zapPath := filepath.Join(t.TempDir(), "zap.log")
+	zapLogger, err := log.NewZap(log.ZapLoggerOpts{
+		Level:             log.LevelInfo,
+		Profile:           log.ProfileDevelopment,
+		Format:            log.FormatConsole,
+		Outputs:           []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: zapPath}}},
+		DisableStacktrace: true,
+	})
+	require.NoError(t, err)
+
+	slogPath := filepath.Join(t.TempDir(), "slog.log")
+	slogLogger, err := log.NewSlog(log.SlogLoggerOpts{
+		Level:             log.LevelInfo,
+		Profile:           log.ProfileDevelopment,
+		Format:            log.FormatConsole,
+		Outputs:           []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: slogPath}}},
+		DisableStacktrace: true,
+	})
+	require.NoError(t, err)
+
+	read := func() []string {
+		var contents []string
+		for _, path := range []string{zapPath, slogPath} {
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			contents = append(contents, string(content))
+		}
+		return contents
+	}
+	return []log.Logger{zapLogger, slogLogger}, read
+}
+
+// assertCallers asserts that every line of the contents reports the caller in the given file.
+func assertCallers(t *testing.T, contents []string, lines int, file string) {
+	t.Helper()
+
+	for _, content := range contents {
+		entries := strings.Split(strings.TrimSpace(content), "\n")
+		assert.Len(t, entries, lines)
+		for _, entry := range entries {
+			assert.Contains(t, entry, "log/"+file+":")
+		}
+	}
+}
+
+func TestTeeLogger_Caller(t *testing.T) {
+	t.Parallel()
+
+	loggers, read := newConsoleFileLoggers(t)
+	tee := log.NewTee(loggers...)
+
+	ctx := context.Background()
+	tee.Info(ctx, "info message")
+	tee.Log(ctx, log.LevelError, "error message")
+	tee.Named("db").With(log.String("key", "value")).Warn(ctx, "warn message")
+	assert.Panics(t, func() {
+		tee.Panic(ctx, "panic message")
+	})
+	require.NoError(t, tee.Sync())
+
+	assertCallers(t, read(), 4, "tee_test.go")
+}
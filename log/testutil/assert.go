@@ -0,0 +1,91 @@
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brpaz/lib-go/log"
+)
+
+// tHelper is implemented by *testing.T, to report failures at the caller of the assertions.
+type tHelper interface {
+	Helper()
+}
+
+// AssertLogged asserts that at least one entry matching all the matchers was logged.
+// Usage:
+//
+//	logger := log.NewInMemory(log.LevelDebug)
+//	// ...
+//	testutil.AssertLogged(t, logger, log.MatchLevel(log.LevelError), log.MatchField("status", 500))
+func AssertLogged(t assert.TestingT, logger *log.InMemoryLogger, matchers ...log.EntryMatcher) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if len(logger.Filter(matchers...)) > 0 {
+		return true
+	}
+	return assert.Fail(t, "No log entry matches the conditions", "Logged entries:\n%s", formatEntries(logger.Entries()))
+}
+
+// AssertNotLogged asserts that no entry matching all the matchers was logged.
+func AssertNotLogged(t assert.TestingT, logger *log.InMemoryLogger, matchers ...log.EntryMatcher) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	entries := logger.Filter(matchers...)
+	if len(entries) == 0 {
+		return true
+	}
+	return assert.Fail(t, "Unexpected log entries match the conditions", "Matching entries:\n%s", formatEntries(entries))
+}
+
+// AssertLoggedTimes asserts that exactly count entries matching all the matchers were logged.
+func AssertLoggedTimes(t assert.TestingT, logger *log.InMemoryLogger, count int, matchers ...log.EntryMatcher) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	entries := logger.Filter(matchers...)
+	if len(entries) == count {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("Expected %d log entries to match the conditions, got %d", count, len(entries)),
+		"Logged entries:\n%s", formatEntries(logger.Entries()))
+}
+
+// AssertEventuallyLogged asserts that an entry matching all the matchers is logged within the timeout.
+// It is useful to assert the entries logged by other goroutines, like HTTP handlers or workers.
+func AssertEventuallyLogged(t assert.TestingT, logger *log.InMemoryLogger, timeout time.Duration, matchers ...log.EntryMatcher) bool {
+	if h, ok := t.(tHelper); ok {
+		h.Helper()
+	}
+
+	if _, ok := logger.WaitFor(timeout, matchers...); ok {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("No log entry matched the conditions within %s", timeout),
+		"Logged entries:\n%s", formatEntries(logger.Entries()))
+}
+
+// formatEntries formats the entries for the failure messages, one entry per line.
+func formatEntries(entries []log.InMemoryLogEntry) string {
+	if len(entries) == 0 {
+		return "\t(none)"
+	}
+
+	var sb strings.Builder
+	for _, entry := range entries {
+		fmt.Fprintf(&sb, "\t[%s] %s", entry.Level, entry.Message)
+		for _, f := range entry.Fields {
+			fmt.Fprintf(&sb, " %s=%v", f.Key, f.Value())
+		}
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
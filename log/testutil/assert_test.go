@@ -0,0 +1,80 @@
+package testutil_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/brpaz/lib-go/log"
+	"github.com/brpaz/lib-go/log/testutil"
+)
+
+// mockT records the failures reported by the assertions.
+type mockT struct {
+	failures []string
+}
+
+func (m *mockT) Errorf(format string, args ...any) {
+	m.failures = append(m.failures, fmt.Sprintf(format, args...))
+}
+
+func newTestLogger() *log.InMemoryLogger {
+	logger := log.NewInMemory(log.LevelDebug)
+	logger.Info(context.Background(), "request handled", log.Int("status", 200))
+	logger.Error(context.Background(), "request failed", log.Int("status", 500))
+	logger.Error(context.Background(), "request failed", log.Int("status", 503))
+	return logger
+}
+
+func TestAssertLogged(t *testing.T) {
+	logger := newTestLogger()
+
+	assert.True(t, testutil.AssertLogged(t, logger, log.MatchMessage("request handled")))
+
+	mt := &mockT{}
+	assert.False(t, testutil.AssertLogged(mt, logger, log.MatchMessage("request handled"), log.MatchField("status", 500)))
+	assert.Len(t, mt.failures, 1)
+	assert.Contains(t, mt.failures[0], "No log entry matches the conditions")
+	assert.Contains(t, mt.failures[0], "[info] request handled status=200")
+}
+
+func TestAssertNotLogged(t *testing.T) {
+	logger := newTestLogger()
+
+	assert.True(t, testutil.AssertNotLogged(t, logger, log.MatchLevel(log.LevelWarn)))
+
+	mt := &mockT{}
+	assert.False(t, testutil.AssertNotLogged(mt, logger, log.MatchLevel(log.LevelError)))
+	assert.Len(t, mt.failures, 1)
+	assert.Contains(t, mt.failures[0], "[error] request failed status=503")
+}
+
+func TestAssertLoggedTimes(t *testing.T) {
+	logger := newTestLogger()
+
+	assert.True(t, testutil.AssertLoggedTimes(t, logger, 2, log.MatchMessage("request failed")))
+
+	mt := &mockT{}
+	assert.False(t, testutil.AssertLoggedTimes(mt, logger, 1, log.MatchMessage("request failed")))
+	assert.Len(t, mt.failures, 1)
+	assert.Contains(t, mt.failures[0], "Expected 1 log entries to match the conditions, got 2")
+}
+
+func TestAssertEventuallyLogged(t *testing.T) {
+	logger := log.NewInMemory(log.LevelDebug)
+
+	go func() {
+		time.Sleep(10 * time.Millisecond)
+		logger.Info(context.Background(), "job done")
+	}()
+
+	assert.True(t, testutil.AssertEventuallyLogged(t, logger, time.Second, log.MatchMessage("job done")))
+
+	mt := &mockT{}
+	assert.False(t, testutil.AssertEventuallyLogged(mt, logger, 10*time.Millisecond, log.MatchMessage("job failed")))
+	assert.Len(t, mt.failures, 1)
+	assert.Contains(t, mt.failures[0], "[info] job done")
+}
@@ -0,0 +1,2 @@
+// Package testutil provides helpers to assert the entries logged to a log.InMemoryLogger in tests.
+package testutil
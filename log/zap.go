@@ -2,24 +2,52 @@ package log
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"runtime"
 	"slices"
+	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// zapTraceLevel is the zap level used for the Trace level, one step below zap's debug level.
+const zapTraceLevel = zapcore.DebugLevel - 1
+
 // ZapAdapter is a wrapper around zap.Logger that implements the Logger interface.
 type ZapAdapter struct {
 	ZapL        *zap.Logger
 	AtomicLevel zap.AtomicLevel
+	name        string
+	registry    *LevelRegistry
+	extractors  []ContextExtractor
+	// closers close the writers of the outputs, shared by the child loggers.
+	closers []io.Closer
 }
 
 // ZapLoggerOpts specifies options to configure the Zap logger instance.
 type ZapLoggerOpts struct {
 	Level   Level
 	Profile string
+	// Format is the format of the entries. When empty, it defaults to console in the development
+	// profile and json in the production profile.
 	Format  string
+	Outputs []Output
+	// ContextExtractors extract the fields added to every entry from the context.
+	// Defaults to DefaultContextExtractors when nil, while an empty slice disables the extraction.
+	ContextExtractors []ContextExtractor
+	// StacktraceLevel is the minimum level of the entries that include a stack trace.
+	// When nil, it defaults to Warn in the development profile and Error in the production profile.
+	StacktraceLevel *Level
+	// DisableStacktrace disables the stack traces, for every level.
+	DisableStacktrace bool
+	// DisableSampling disables the sampling of zap in the production profile, which logs the first
+	// 100 entries with the same level and message per second, and then every 100th entry.
+	// New disables it when the entries are sampled by a SampledLogger (see WithSampling).
+	DisableSampling bool
 }
 
 func (o ZapLoggerOpts) Validate() error {
@@ -31,7 +59,7 @@ func (o ZapLoggerOpts) Validate() error {
 		return fmt.Errorf("%w: %s", ErrInvalidFormat, o.Format)
 	}
 
-	return nil
+	return validateOutputs(o.Outputs)
 }
 
 // defaultZapLoggerOpts defines the default options for the Zap logger.
@@ -48,24 +76,37 @@ func DefaultZapLoggerOpts() ZapLoggerOpts {
 
 // NewZap creates a new Zap logger instance with the provided options.
 func NewZap(opts ZapLoggerOpts) (*ZapAdapter, error) {
+	if opts.Format == "" {
+		opts.Format = profileFormat(opts.Profile)
+	}
 	if err := opts.Validate(); err != nil {
 		return nil, fmt.Errorf("failed to create zap logger: %w", err)
 	}
 
-	zapLogger, atomicLevel, err := createZapLogger(opts)
+	zapLogger, atomicLevel, closers, err := createZapLogger(opts)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create zap logger: %w", err)
 	}
 	return &ZapAdapter{
 		ZapL:        zapLogger,
 		AtomicLevel: atomicLevel,
+		registry:    NewLevelRegistry(),
+		extractors:  opts.ContextExtractors,
+		closers:     closers,
 	}, nil
 }
 
 // log handles logging at various levels.
 func (l *ZapAdapter) log(ctx context.Context, lvl zapcore.Level, msg string, fields ...Field) {
-	fields = append(fields, ExtractTraceIDFieldsFromContext(ctx)...)
-	l.ZapL.Log(lvl, msg, fields...)
+	if hasStacktraceField(fields) {
+		// The logger adds the stack traces, so the entries with their own are written without it.
+		// Zap still panics or exits for the Panic and Fatal levels.
+		l.writeEntry(ctx, entry{level: fromZapLevel(lvl), msg: msg, fields: fields, skip: 2, terminate: true})
+		return
+	}
+
+	fields = append(fields, extractContextFields(ctx, l.extractors)...)
+	l.ZapL.Log(lvl, msg, toZapFields(fields)...)
 }
 
 // Info logs a message at the info level.
@@ -88,6 +129,75 @@ func (l *ZapAdapter) Debug(ctx context.Context, msg string, fields ...Field) {
 	l.log(ctx, zap.DebugLevel, msg, fields...)
 }
 
+// Trace logs a message at the trace level.
+func (l *ZapAdapter) Trace(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, zapTraceLevel, msg, fields...)
+}
+
+// Panic logs a message at the panic level and then panics.
+func (l *ZapAdapter) Panic(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, zap.PanicLevel, msg, fields...)
+}
+
+// Fatal logs a message at the fatal level, flushes the logger and then calls os.Exit(1).
+func (l *ZapAdapter) Fatal(ctx context.Context, msg string, fields ...Field) {
+	l.log(ctx, zap.FatalLevel, msg, fields...)
+}
+
+// Log logs a message at the given level. Messages at the panic and fatal levels
+// are written without panicking or exiting.
+func (l *ZapAdapter) Log(ctx context.Context, level Level, msg string, fields ...Field) {
+	lvl := toZapLevel(level)
+	if lvl < zap.DPanicLevel {
+		l.log(ctx, lvl, msg, fields...)
+		return
+	}
+
+	l.writeEntry(ctx, entry{level: level, msg: msg, fields: fields, skip: 1})
+}
+
+// writeEntry writes an entry of a logger that wraps this logger.
+func (l *ZapAdapter) writeEntry(ctx context.Context, e entry) {
+	lvl := toZapLevel(e.level)
+
+	// The logger already skips two frames, for log and the public level method, while
+	// writeEntry is preceded by the e.skip frames.
+	logger := l.ZapL
+	var opts []zap.Option
+	if e.skip != 1 {
+		opts = append(opts, zap.AddCallerSkip(e.skip-1))
+	}
+	if !e.terminate && lvl >= zap.DPanicLevel {
+		opts = append(opts, zap.WithPanicHook(noopCheckWriteHook{}), zap.WithFatalHook(noopCheckWriteHook{}))
+	}
+	if hasStacktraceField(e.fields) {
+		opts = append(opts, zap.AddStacktrace(zap.LevelEnablerFunc(func(zapcore.Level) bool { return false })))
+	}
+	if len(opts) > 0 {
+		logger = logger.WithOptions(opts...)
+	}
+
+	ce := logger.Check(lvl, e.msg)
+	if ce == nil {
+		return
+	}
+	if !e.time.IsZero() {
+		ce.Time = e.time
+	}
+	if e.pc != 0 && ce.Caller.Defined {
+		frame, _ := runtime.CallersFrames([]uintptr{e.pc}).Next()
+		ce.Caller = zapcore.EntryCaller{Defined: true, PC: e.pc, File: frame.File, Line: frame.Line, Function: frame.Function}
+	}
+	ce.Write(toZapFields(append(e.fields, extractContextFields(ctx, l.extractors)...))...)
+}
+
+// noopCheckWriteHook is a zapcore.CheckWriteHook that does nothing after writing an entry.
+// Unlike zapcore.WriteThenNoop, zap does not replace it with its default panic or fatal behavior.
+type noopCheckWriteHook struct{}
+
+// OnWrite implements zapcore.CheckWriteHook.
+func (noopCheckWriteHook) OnWrite(*zapcore.CheckedEntry, []zapcore.Field) {}
+
 // SetLevel updates the logging level dynamically.
 func (l *ZapAdapter) SetLevel(level Level) error {
 	l.AtomicLevel.SetLevel(toZapLevel(level))
@@ -101,29 +211,135 @@ func (l *ZapAdapter) GetLevel() Level {
 
 // With creates a new logger instance with additional fields.
 func (l *ZapAdapter) With(fields ...Field) Logger {
-	childLogger := l.ZapL.With(fields...)
+	childLogger := l.ZapL.With(toZapFields(fields)...)
 	return &ZapAdapter{
 		ZapL:        childLogger,
 		AtomicLevel: l.AtomicLevel,
+		name:        l.name,
+		registry:    l.registry,
+		extractors:  l.extractors,
+		closers:     l.closers,
 	}
 }
 
+// Named creates a new logger instance with the given name appended to the logger name,
+// and a level of its own, starting at the current level of the parent logger.
+func (l *ZapAdapter) Named(name string) Logger {
+	fullName := joinLoggerName(l.name, name)
+	return l.registry.register(fullName, func(registered Logger) Logger {
+		level := zap.NewAtomicLevelAt(l.AtomicLevel.Level())
+		if named, ok := registered.(*ZapAdapter); ok {
+			level = named.AtomicLevel
+		}
+
+		return &ZapAdapter{
+			ZapL: l.ZapL.Named(name).WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+				return newZapLevelCore(core, level)
+			})),
+			AtomicLevel: level,
+			name:        fullName,
+			registry:    l.registry,
+			extractors:  l.extractors,
+			closers:     l.closers,
+		}
+	})
+}
+
+// Registry returns the registry of the named loggers created from this logger.
+func (l *ZapAdapter) Registry() *LevelRegistry {
+	return l.registry
+}
+
 // Sync flushes any buffered log entries.
 func (l *ZapAdapter) Sync() error {
 	return l.ZapL.Sync()
 }
 
+// Close flushes the buffered log entries and closes the files of the file outputs.
+// It closes the files of the whole logger tree, including the loggers created with With and Named.
+func (l *ZapAdapter) Close() error {
+	return errors.Join(l.Sync(), closeOutputs(l.closers))
+}
+
 // createZapLogger initializes a Zap logger with the given options.
-func createZapLogger(opts ZapLoggerOpts) (*zap.Logger, zap.AtomicLevel, error) {
-	atomicLevel := zap.NewAtomicLevel()
-	atomicLevel.SetLevel(toZapLevel(opts.Level))
+func createZapLogger(opts ZapLoggerOpts) (*zap.Logger, zap.AtomicLevel, []io.Closer, error) {
+	atomicLevel := zap.NewAtomicLevelAt(toZapLevel(opts.Level))
 
 	config, err := getZapConfig(opts)
 	if err != nil {
-		return nil, atomicLevel, fmt.Errorf("failed to build zap config: %w", err)
+		return nil, atomicLevel, nil, fmt.Errorf("failed to build zap config: %w", err)
+	}
+
+	writers, closers, err := newOutputWriters(opts.Outputs)
+	if err != nil {
+		return nil, atomicLevel, nil, fmt.Errorf("failed to open log outputs: %w", err)
+	}
+
+	syncers := make([]zapcore.WriteSyncer, len(writers))
+	for i, w := range writers {
+		syncers[i] = zapcore.AddSync(w)
 	}
-	logger, err := config.Build(zap.AddCallerSkip(2))
-	return logger, atomicLevel, err
+
+	color := opts.Format == FormatConsole && colorEnabled(writers)
+	encoder := newZapEncoder(opts.Format, config.EncoderConfig, color)
+
+	// The level is checked by the outermost core, so that named loggers can replace it.
+	core := zapcore.NewCore(encoder, zapcore.Lock(zapcore.NewMultiWriteSyncer(syncers...)), zap.LevelEnablerFunc(func(zapcore.Level) bool {
+		return true
+	}))
+	if config.Sampling != nil && !opts.DisableSampling {
+		core = zapcore.NewSamplerWithOptions(core, time.Second, config.Sampling.Initial, config.Sampling.Thereafter)
+	}
+	core = newZapLevelCore(core, atomicLevel)
+
+	zapOpts := []zap.Option{zap.AddCallerSkip(2), zap.ErrorOutput(zapcore.Lock(os.Stderr))}
+	if config.Development {
+		zapOpts = append(zapOpts, zap.Development(), zap.AddCaller())
+	}
+	if level, ok := stacktraceLevel(opts.Profile, opts.StacktraceLevel, opts.DisableStacktrace); ok {
+		zapOpts = append(zapOpts, zap.AddStacktrace(toZapLevel(level)))
+	}
+
+	return zap.New(core, zapOpts...), atomicLevel, closers, nil
+}
+
+// zapLevelCore is a zapcore.Core that filters the entries using its own level,
+// before passing them to the wrapped core.
+type zapLevelCore struct {
+	zapcore.Core
+	level zap.AtomicLevel
+}
+
+// newZapLevelCore wraps the core with the given level, replacing the level of the core
+// if it is already wrapped.
+func newZapLevelCore(core zapcore.Core, level zap.AtomicLevel) zapcore.Core {
+	if levelCore, ok := core.(*zapLevelCore); ok {
+		core = levelCore.Core
+	}
+	return &zapLevelCore{Core: core, level: level}
+}
+
+// Enabled reports whether the given level is enabled.
+func (c *zapLevelCore) Enabled(lvl zapcore.Level) bool {
+	return c.level.Enabled(lvl)
+}
+
+// Level returns the minimum enabled level.
+func (c *zapLevelCore) Level() zapcore.Level {
+	return c.level.Level()
+}
+
+// With adds the fields to the wrapped core, keeping the level.
+func (c *zapLevelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &zapLevelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+// Check passes the entry to the wrapped core if its level is enabled.
+func (c *zapLevelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(ent.Level) {
+		return ce
+	}
+	return c.Core.Check(ent, ce)
 }
 
 // getZapConfig returns the appropriate zap.Config for the given profile and options.
@@ -136,31 +352,123 @@ func getZapConfig(opts ZapLoggerOpts) (zap.Config, error) {
 		config = zap.NewProductionConfig()
 	}
 
-	config.Encoding = getZapEncoding(opts.Format)
 	config.EncoderConfig = getZapEncoderConfig()
 	return config, nil
 }
 
-// getZapEncoding returns the encoding type based on format.
-func getZapEncoding(format string) string {
-	if format == FormatLogFmt {
-		return "console"
+// newZapEncoder returns the encoder for the given format.
+// Level colors are only used by the console format, when color is enabled.
+func newZapEncoder(format string, cfg zapcore.EncoderConfig, color bool) zapcore.Encoder {
+	switch format {
+	case FormatLogFmt:
+		return NewLogfmtEncoder(cfg)
+	case FormatConsole:
+		cfg.EncodeLevel = withTraceLevel(zapcore.CapitalLevelEncoder, "TRACE")
+		if color {
+			cfg.EncodeLevel = withTraceLevel(zapcore.CapitalColorLevelEncoder, "TRACE")
+		}
+		cfg.ConsoleSeparator = "  "
+		return zapcore.NewConsoleEncoder(cfg)
+	default:
+		return zapcore.NewJSONEncoder(cfg)
 	}
-	return "json"
 }
 
 // getZapEncoderConfig defines common encoder configurations.
 func getZapEncoderConfig() zapcore.EncoderConfig {
 	return zapcore.EncoderConfig{
-		EncodeLevel:    zapcore.CapitalColorLevelEncoder,
+		TimeKey:        "ts",
+		LevelKey:       "level",
+		NameKey:        "logger",
+		CallerKey:      "caller",
+		MessageKey:     "msg",
+		StacktraceKey:  stacktraceKey,
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    withTraceLevel(zapcore.LowercaseLevelEncoder, "trace"),
 		EncodeTime:     zapcore.ISO8601TimeEncoder,
 		EncodeDuration: zapcore.StringDurationEncoder,
+		EncodeCaller:   zapcore.ShortCallerEncoder,
+	}
+}
+
+// withTraceLevel wraps a level encoder, encoding the trace level with the given name,
+// since zap has no name for levels below debug.
+func withTraceLevel(encode zapcore.LevelEncoder, name string) zapcore.LevelEncoder {
+	return func(lvl zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+		if lvl == zapTraceLevel {
+			enc.AppendString(name)
+			return
+		}
+		encode(lvl, enc)
+	}
+}
+
+// zapLevelName returns the lowercase name of a zap level, including the trace level.
+func zapLevelName(lvl zapcore.Level) string {
+	if lvl == zapTraceLevel {
+		return LevelTrace.String()
+	}
+	return lvl.String()
+}
+
+// toZapFields converts log fields to zap fields, adding the fields that describe the errors.
+func toZapFields(fields []Field) []zap.Field {
+	fields = expandErrorFields(fields)
+	zapFields := make([]zap.Field, len(fields))
+	for i, f := range fields {
+		zapFields[i] = toZapField(f)
+	}
+	return zapFields
+}
+
+// toZapField converts a single log field to a zap field.
+func toZapField(f Field) zap.Field {
+	switch f.Type {
+	case StringType:
+		return zap.String(f.Key, f.String)
+	case BoolType:
+		return zap.Bool(f.Key, f.Integer == 1)
+	case Int64Type:
+		return zap.Int64(f.Key, f.Integer)
+	case Uint64Type:
+		return zap.Uint64(f.Key, uint64(f.Integer))
+	case Float64Type:
+		return zap.Float64(f.Key, f.Float)
+	case Float32Type:
+		return zap.Float32(f.Key, float32(f.Float))
+	case DurationType:
+		return zap.Duration(f.Key, time.Duration(f.Integer))
+	case TimeType:
+		t, _ := f.Interface.(time.Time)
+		return zap.Time(f.Key, t)
+	case ErrorType:
+		err, _ := f.Interface.(error)
+		return zap.NamedError(f.Key, err)
+	case StringerType:
+		return zap.String(f.Key, stringerValue(f.Interface))
+	case GroupType, ObjectType:
+		return zap.Object(f.Key, zapFieldsMarshaler(f.Fields()))
+	default:
+		return zap.Any(f.Key, f.Interface)
 	}
 }
 
+// zapFieldsMarshaler encodes a list of log fields as a zap object.
+type zapFieldsMarshaler []Field
+
+// MarshalLogObject implements zapcore.ObjectMarshaler.
+func (fields zapFieldsMarshaler) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, f := range expandErrorFields(fields) {
+		toZapField(f).AddTo(enc)
+	}
+	return nil
+}
+
 // toZapLevel converts a Level to a zapcore.Level.
 func toZapLevel(level Level) zapcore.Level {
 	switch level {
+	case LevelTrace:
+		return zapTraceLevel
 	case LevelDebug:
 		return zapcore.DebugLevel
 	case LevelInfo:
@@ -169,6 +477,10 @@ func toZapLevel(level Level) zapcore.Level {
 		return zapcore.WarnLevel
 	case LevelError:
 		return zapcore.ErrorLevel
+	case LevelPanic:
+		return zapcore.PanicLevel
+	case LevelFatal:
+		return zapcore.FatalLevel
 	default:
 		return zapcore.InfoLevel
 	}
@@ -177,14 +489,20 @@ func toZapLevel(level Level) zapcore.Level {
 // fromZapLevel converts a zapcore.Level to a Level.
 func fromZapLevel(level zapcore.Level) Level {
 	switch level {
+	case zapTraceLevel:
+		return LevelTrace
 	case zapcore.DebugLevel:
 		return LevelDebug
 	case zapcore.InfoLevel:
 		return LevelInfo
 	case zapcore.WarnLevel:
 		return LevelWarn
-	case zapcore.ErrorLevel:
+	case zapcore.ErrorLevel, zapcore.DPanicLevel:
 		return LevelError
+	case zapcore.PanicLevel:
+		return LevelPanic
+	case zapcore.FatalLevel:
+		return LevelFatal
 	default:
 		return LevelInfo
 	}
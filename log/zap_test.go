@@ -4,6 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -160,6 +164,113 @@ func TestZapAdapter_Debug(t *testing.T) {
 	assertContainsJSONField(t, output, "key", "value")
 }
 
+func TestZapAdapter_Trace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{format: log.FormatJSON, expected: `"level":"trace"`},
+		{format: log.FormatLogFmt, expected: "level=trace"},
+		{format: log.FormatConsole, expected: "TRACE"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "app.log")
+
+			logger, err := log.NewZap(log.ZapLoggerOpts{
+				Level:   log.LevelTrace,
+				Profile: log.ProfileProduction,
+				Format:  tt.format,
+				Outputs: []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+			})
+			require.NoError(t, err)
+			assert.Equal(t, log.LevelTrace, logger.GetLevel())
+
+			logger.Trace(context.Background(), "trace message")
+			require.NoError(t, logger.SetLevel(log.LevelDebug))
+			logger.Trace(context.Background(), "discarded trace message")
+			require.NoError(t, logger.Sync())
+
+			content, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Contains(t, string(content), tt.expected)
+			assert.Contains(t, string(content), "trace message")
+			assert.NotContains(t, string(content), "discarded")
+		})
+	}
+}
+
+func TestZapAdapter_Panic(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := createTestLogger(buf, zapcore.DebugLevel)
+
+	assert.PanicsWithValue(t, "panic message", func() {
+		logger.Panic(context.Background(), "panic message", log.String("key", "value"))
+	})
+
+	assertContainsJSONField(t, buf.String(), "level", "panic")
+	assertContainsJSONField(t, buf.String(), "key", "value")
+}
+
+func TestZapAdapter_Log(t *testing.T) {
+	tests := []struct {
+		level    log.Level
+		expected string
+	}{
+		{level: log.LevelInfo, expected: "info"},
+		{level: log.LevelPanic, expected: "panic"},
+		{level: log.LevelFatal, expected: "fatal"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			logger := createTestLogger(buf, zapcore.DebugLevel)
+
+			assert.NotPanics(t, func() {
+				logger.Log(context.Background(), tt.level, "message")
+			})
+
+			assertContainsJSONField(t, buf.String(), "level", tt.expected)
+			assertContainsJSONField(t, buf.String(), "msg", "message")
+		})
+	}
+}
+
+func TestZapAdapter_Named(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "app.log")
+
+	logger, err := log.NewZap(log.ZapLoggerOpts{
+		Level:   log.LevelInfo,
+		Profile: log.ProfileProduction,
+		Format:  log.FormatJSON,
+		Outputs: []log.Output{{Type: log.OutputFile, File: log.FileOutputOpts{Path: path}}},
+	})
+	require.NoError(t, err)
+
+	dbLogger := logger.Named("db")
+	require.NoError(t, dbLogger.SetLevel(log.LevelDebug))
+
+	ctx := context.Background()
+	dbLogger.With(log.String("key", "value")).Debug(ctx, "db debug message")
+	logger.Debug(ctx, "discarded debug message")
+	logger.Named("http").Debug(ctx, "discarded http debug message")
+	require.NoError(t, logger.Sync())
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, log.LevelInfo, logger.GetLevel())
+	assertContainsJSONField(t, string(content), "logger", "db")
+	assertContainsJSONField(t, string(content), "msg", "db debug message")
+	assertContainsJSONField(t, string(content), "key", "value")
+}
+
 func TestZapAdapter_SetLevel(t *testing.T) {
 	logger, err := log.NewZap(log.DefaultZapLoggerOpts())
 	require.NoError(t, err)
@@ -201,3 +312,23 @@ func TestZapAdapter_WithFields(t *testing.T) {
 	assertContainsJSONField(t, output, "field1", "value1")
 	assertContainsJSONField(t, output, "msg", "info message")
 }
+
+func TestZapAdapter_FieldEncoding(t *testing.T) {
+	buf := &bytes.Buffer{}
+	logger := createTestLogger(buf, zapcore.DebugLevel)
+
+	logger.Info(context.Background(), "info message",
+		log.Err(errors.New("boom")),
+		log.Bool("enabled", true),
+		log.Stringer("url", &url.URL{Scheme: "https", Host: "example.com"}),
+		log.Group("request", log.String("method", "GET"), log.Int("status", 200)),
+	)
+
+	var data map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+
+	assert.Equal(t, "boom", data["error"])
+	assert.Equal(t, true, data["enabled"])
+	assert.Equal(t, "https://example.com", data["url"])
+	assert.Equal(t, map[string]any{"method": "GET", "status": float64(200)}, data["request"])
+}
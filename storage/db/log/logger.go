@@ -136,7 +136,7 @@ func (gl *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql
 	switch {
 	case err != nil && (!gl.ignoreRecordNotFoundError || !errors.Is(err, gorm.ErrRecordNotFound)):
 		gl.logger.Error(ctx, "SQL execution error",
-			log.Error(err),
+			log.Err(err),
 			log.Duration("elapsed", elapsed),
 			log.Int64("rows", rows),
 			log.String("sql", sql),
@@ -148,7 +148,7 @@ func (gl *GormLogger) Trace(ctx context.Context, begin time.Time, fc func() (sql
 			log.String("sql", sql),
 		)
 	default:
-		gl.logger.Debug(ctx, "SQL query trace",
+		gl.logger.Trace(ctx, "SQL query trace",
 			log.Duration("elapsed", elapsed),
 			log.Int64("rows", rows),
 			log.String("sql", sql),
@@ -163,9 +163,9 @@ func mapLogLevel(level log.Level) gorml.LogLevel {
 		return gorml.Info
 	case log.LevelWarn:
 		return gorml.Warn
-	case log.LevelError:
+	case log.LevelError, log.LevelPanic, log.LevelFatal:
 		return gorml.Error
-	case log.LevelDebug:
+	case log.LevelTrace, log.LevelDebug:
 		return gorml.Info
 	default:
 		return gorml.Info
@@ -60,6 +60,11 @@ func TestMapLogLevels(t *testing.T) {
 		level    log.Level
 		expected gorml.LogLevel
 	}{
+		{
+			name:     "Trace",
+			level:    log.LevelTrace,
+			expected: gorml.Info,
+		},
 		{
 			name:     "Debug",
 			level:    log.LevelDebug,
@@ -80,6 +85,11 @@ func TestMapLogLevels(t *testing.T) {
 			level:    log.LevelError,
 			expected: gorml.Error,
 		},
+		{
+			name:     "Fatal",
+			level:    log.LevelFatal,
+			expected: gorml.Error,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -206,7 +216,7 @@ func TestTrace(t *testing.T) {
 
 	t.Run("ShouldLogTrace", func(t *testing.T) {
 		t.Parallel()
-		inMemoryLogger := log.NewInMemory(log.LevelDebug)
+		inMemoryLogger := log.NewInMemory(log.LevelTrace)
 		logger := dblog.NewGormLogger(inMemoryLogger)
 		logger.Trace(context.Background(), time.Now(), func() (string, int64) {
 			return "SELECT * FROM users", 0
@@ -214,6 +224,18 @@ func TestTrace(t *testing.T) {
 
 		assert.Equal(t, 1, len(inMemoryLogger.Entries()))
 		assert.Equal(t, "SQL query trace", inMemoryLogger.Entries()[0].Message)
+		assert.Equal(t, "trace", inMemoryLogger.Entries()[0].Level)
+	})
+
+	t.Run("ShouldNotLogTraceAtDebugLevel", func(t *testing.T) {
+		t.Parallel()
+		inMemoryLogger := log.NewInMemory(log.LevelDebug)
+		logger := dblog.NewGormLogger(inMemoryLogger)
+		logger.Trace(context.Background(), time.Now(), func() (string, int64) {
+			return "SELECT * FROM users", 0
+		}, nil)
+
+		assert.Empty(t, inMemoryLogger.Entries())
 	})
 
 	t.Run("ShouldNotLogSilent", func(t *testing.T) {
@@ -241,7 +263,7 @@ func TestTrace_Integration(t *testing.T) {
 	require.NoError(t, dsnErr)
 
 	t.Run("Log Query", func(t *testing.T) {
-		memoryLogger := log.NewInMemory(log.LevelDebug)
+		memoryLogger := log.NewInMemory(log.LevelTrace)
 
 		gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 			Logger: dblog.NewGormLogger(memoryLogger),
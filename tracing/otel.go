@@ -6,12 +6,16 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/stdout/stdoutlog"
 	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/log/global"
 	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 
+	sdkLog "go.opentelemetry.io/otel/sdk/log"
 	sdkTrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 
@@ -30,6 +34,8 @@ type OtelOpts struct {
 	Envrionment      string
 	ConsoleExporter  bool
 	OtlpGrpcExporter bool
+	// Logs enables the OpenTelemetry Logs SDK, exporting log records with the same resource and exporter as the traces.
+	Logs bool
 }
 
 // OtelOptFunc is a functional option type for configuring OtelOpts.
@@ -84,6 +90,15 @@ func WithOtlpGrpcExporter() OtelOptFunc {
 	}
 }
 
+// WithLogs enables the OpenTelemetry Logs SDK. The log records are exported with the same resource
+// and exporter as the traces, and the logger provider is set as the global logger provider,
+// which is used by the otel adapter of the log package.
+func WithLogs() OtelOptFunc {
+	return func(o *OtelOpts) {
+		o.Logs = true
+	}
+}
+
 // SetupOtelSDK bootstraps the OpenTelemetry SDK and returns a shutdown function.
 func SetupOtelSDK(ctx context.Context, options ...OtelOptFunc) (shutdown func(context.Context) error, err error) {
 	opts := defaultOtelOpts
@@ -109,8 +124,14 @@ func SetupOtelSDK(ctx context.Context, options ...OtelOptFunc) (shutdown func(co
 	// Set up propagator
 	otel.SetTextMapPropagator(newPropagator())
 
+	// The resource is shared by the traces and the logs, so that they can be correlated
+	res, err := newResource(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set up trace provider
-	tracerProvider, err := newTraceProvider(ctx, opts)
+	tracerProvider, err := newTraceProvider(ctx, opts, res)
 	if err != nil {
 		return nil, err
 	}
@@ -119,6 +140,17 @@ func SetupOtelSDK(ctx context.Context, options ...OtelOptFunc) (shutdown func(co
 	shutdownFuncs = append(shutdownFuncs, tracerProvider.Shutdown)
 	otel.SetTracerProvider(tracerProvider)
 
+	// Set up logger provider
+	if opts.Logs {
+		loggerProvider, err := newLoggerProvider(ctx, opts, res)
+		if err != nil {
+			return nil, errors.Join(err, shutdown(ctx))
+		}
+
+		shutdownFuncs = append(shutdownFuncs, loggerProvider.Shutdown)
+		global.SetLoggerProvider(loggerProvider)
+	}
+
 	return shutdown, nil
 }
 
@@ -131,17 +163,12 @@ func newPropagator() propagation.TextMapPropagator {
 }
 
 // newTraceProvider creates a new tracer provider with the appropriate exporter.
-func newTraceProvider(ctx context.Context, opts OtelOpts) (*sdkTrace.TracerProvider, error) {
+func newTraceProvider(ctx context.Context, opts OtelOpts, res *resource.Resource) (*sdkTrace.TracerProvider, error) {
 	traceExporter, err := newTraceExporter(ctx, opts)
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := newResource(ctx, opts)
-	if err != nil {
-		return nil, err
-	}
-
 	traceProvider := sdkTrace.NewTracerProvider(
 		sdkTrace.WithResource(res),
 		sdkTrace.WithSampler(sdkTrace.ParentBased(sdkTrace.TraceIDRatioBased(0.8))),
@@ -178,3 +205,32 @@ func newTraceExporter(ctx context.Context, opts OtelOpts) (sdkTrace.SpanExporter
 
 	return libExporter.NewNoopExporter(), nil
 }
+
+// newLoggerProvider creates a new logger provider with the appropriate exporter.
+// Without an exporter, the log records are dropped.
+func newLoggerProvider(ctx context.Context, opts OtelOpts, res *resource.Resource) (*sdkLog.LoggerProvider, error) {
+	providerOpts := []sdkLog.LoggerProviderOption{sdkLog.WithResource(res)}
+
+	logExporter, err := newLogExporter(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	if logExporter != nil {
+		providerOpts = append(providerOpts, sdkLog.WithProcessor(sdkLog.NewBatchProcessor(logExporter)))
+	}
+
+	return sdkLog.NewLoggerProvider(providerOpts...), nil
+}
+
+// newLogExporter returns the appropriate log exporter based on configuration, or nil if none is enabled.
+func newLogExporter(ctx context.Context, opts OtelOpts) (sdkLog.Exporter, error) {
+	if opts.ConsoleExporter {
+		return stdoutlog.New(stdoutlog.WithPrettyPrint())
+	}
+
+	if opts.OtlpGrpcExporter {
+		return otlploggrpc.New(ctx)
+	}
+
+	return nil, nil
+}
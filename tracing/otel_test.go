@@ -6,6 +6,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/log/global"
+	sdklog "go.opentelemetry.io/otel/sdk/log"
 
 	"github.com/brpaz/lib-go/tracing"
 )
@@ -52,6 +54,24 @@ func TestSetupOtelSDKWithOtlpGrpcExporter(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestSetupOtelSDK_WithLogs(t *testing.T) {
+	opts := []tracing.OtelOptFunc{
+		tracing.WithServiceName("test-service"),
+		tracing.WithServiceVersion("1.0.0"),
+		tracing.WithConsoleExporter(),
+		tracing.WithLogs(),
+	}
+
+	shutdown, err := tracing.SetupOtelSDK(context.Background(), opts...)
+	require.NoError(t, err)
+
+	// The logger provider of the SDK is set as the global logger provider.
+	assert.IsType(t, &sdklog.LoggerProvider{}, global.GetLoggerProvider())
+
+	err = shutdown(context.Background())
+	require.NoError(t, err)
+}
+
 func TestSetupOtel_Arguments(t *testing.T) {
 	t.Parallel()
 